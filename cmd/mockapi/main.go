@@ -0,0 +1,44 @@
+// Command mockapi serves a fake NeuralNexus API for running the bot locally.
+//
+//	go run ./cmd/mockapi -addr :8081 -admins 123456789012345678
+//	NEURALNEXUS_API=http://localhost:8081 go run .
+//
+// Faults can be changed while it runs:
+//
+//	curl -X PUT localhost:8081/_mock/faults -d '{"latency":"2s","error_rate":0.5,"malformed_rate":0.1}'
+//	curl -X POST localhost:8081/_mock/reset
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/NeuralNexusDev/neuralnexus-discord-bot/src/api/apitest"
+)
+
+func main() {
+	addr := flag.String("addr", ":8081", "address to listen on")
+	apiKey := flag.String("api-key", "", "API key required by the endpoints that change data, none if empty")
+	admins := flag.String("admins", "", "comma separated Discord user IDs allowed to manage bee names")
+	latency := flag.Duration("latency", 0, "latency added to every response")
+	errorRate := flag.Float64("error-rate", 0, "fraction of requests failing with a 500")
+	malformedRate := flag.Float64("malformed-rate", 0, "fraction of requests answered with a malformed body")
+	flag.Parse()
+
+	fixtures := apitest.DefaultFixtures()
+	for _, id := range strings.Split(*admins, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			fixtures.AddAdmin(id, "admin-"+id)
+		}
+	}
+	srv := apitest.NewServer(fixtures)
+	srv.APIKey = *apiKey
+	srv.SetFaults(apitest.Faults{Latency: *latency, ErrorRate: *errorRate, MalformedRate: *malformedRate})
+
+	log.Printf("Serving the mock NeuralNexus API on %s", *addr)
+	server := &http.Server{Addr: *addr, Handler: srv, ReadHeaderTimeout: 10 * time.Second}
+	log.Fatal(server.ListenAndServe())
+}
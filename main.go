@@ -1,17 +1,48 @@
 package main
 
 import (
+	"context"
+	"log"
+	"time"
+
 	"github.com/NeuralNexusDev/neuralnexus-discord-bot/src/discord"
+	"github.com/NeuralNexusDev/neuralnexus-discord-bot/src/discord/modules/audit"
 	"github.com/NeuralNexusDev/neuralnexus-discord-bot/src/discord/modules/bng"
+	"github.com/NeuralNexusDev/neuralnexus-discord-bot/src/discord/modules/config"
+	"github.com/NeuralNexusDev/neuralnexus-discord-bot/src/discord/modules/diagnostics"
 	"github.com/NeuralNexusDev/neuralnexus-discord-bot/src/discord/modules/gss"
+	"github.com/NeuralNexusDev/neuralnexus-discord-bot/src/discord/modules/help"
 	"github.com/NeuralNexusDev/neuralnexus-discord-bot/src/discord/modules/mcstatus"
+	"github.com/NeuralNexusDev/neuralnexus-discord-bot/src/telemetry"
 )
 
 func main() {
+	shutdownTracing, err := telemetry.Setup(context.Background(), diagnostics.Version())
+	if err != nil {
+		log.Fatalf("Cannot set up tracing: %v", err)
+	}
+
 	discordBot := discord.NewBot()
-	discordBot.AddCommandHandler(gss.GSSCommand, gss.GSSHandler)
-	discordBot.AddCommandHandler(mcstatus.MCStatusCommand, mcstatus.MCStatusHandler)
-	discordBot.AddCommandHandler(bng.BeeNameCommand, bng.BeeNameCommandHandler)
-	discordBot.AddComponentHandlers(bng.BeeNameComponentHandlers)
+	discordBot.OnShutdown(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			log.Printf("Cannot flush traces: %v", err)
+		}
+	})
+	discordBot.AddCommand(gss.GSSCommand)
+	discordBot.AddCommandExamples(gss.GSSCommand.Name, gss.GSSExamples...)
+	discordBot.AddComponentHandlers(gss.GSSComponentHandlers(discordBot))
+	discordBot.AddCommand(mcstatus.MCStatusCommand)
+	discordBot.AddCommandExamples(mcstatus.MCStatusCommand.Name, mcstatus.MCStatusExamples...)
+	discordBot.AddComponentHandlers(mcstatus.MCStatusComponentHandlers(discordBot))
+	discordBot.AddCommand(bng.BeeNameCommand)
+	discordBot.AddCommandExamples(bng.BeeNameCommand.Name, bng.BeeNameExamples...)
+	discordBot.AddComponentHandlers(bng.BeeNameComponentHandlers(discordBot))
+	discordBot.AddCoreCommandHandler(config.ConfigCommand(discordBot.Modules()), config.ConfigHandler(discordBot))
+	discordBot.AddCoreCommand(audit.AuditCommand)
+	discordBot.AddOwnerCommandHandler(diagnostics.BotCommand, diagnostics.BotHandler(discordBot))
+	discordBot.AddCoreCommandHandler(help.HelpCommand(discordBot.Commands()), help.HelpHandler(discordBot))
+	discordBot.AddPaginator(help.HelpPaginator)
 	discordBot.Start()
 }
@@ -0,0 +1,136 @@
+package apitest
+
+import (
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/NeuralNexusDev/neuralnexus-discord-bot/src/api"
+)
+
+// Fixtures data the server starts with
+type Fixtures struct {
+	BeeNames    []string
+	Suggestions []string
+	// Users users by user ID
+	Users map[string]api.User
+	// Platforms user IDs by platform account, keyed by PlatformKey
+	Platforms map[string]string
+	// MCServers Minecraft server statuses by host, with BedrockKey for Bedrock Edition servers
+	MCServers map[string]api.MCServerStatus
+	// GameServers game server statuses, keyed by GameServerKey
+	GameServers map[string]api.ServerStatus
+}
+
+// BeeNamePermission permission needed to manage bee names and suggestions
+const BeeNamePermission = "beenamegenerator|*"
+
+// BedrockKey returns the MCServers key of a Bedrock Edition server
+func BedrockKey(host string) string {
+	return host + "|bedrock"
+}
+
+// GameServerKey returns the GameServers key of a server
+func GameServerKey(game, host, port string) string {
+	return game + "|" + host + "|" + port
+}
+
+// PlatformKey returns the Platforms key of a platform account
+func PlatformKey(platform, platformID string) string {
+	return platform + "|" + platformID
+}
+
+// AddAdmin links a user with the bee name permission to the Discord account
+func (f *Fixtures) AddAdmin(discordID, username string) {
+	if f.Users == nil {
+		f.Users = map[string]api.User{}
+	}
+	if f.Platforms == nil {
+		f.Platforms = map[string]string{}
+	}
+	id := "mock-admin-" + discordID
+	f.Users[id] = api.User{
+		UserID:      id,
+		Username:    username,
+		Roles:       []string{"admin"},
+		Permissions: []string{BeeNamePermission},
+		UpdatedAt:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	f.Platforms[PlatformKey("discord", discordID)] = id
+}
+
+// DefaultFixtures returns fixtures covering the bot's commands and their examples
+func DefaultFixtures() Fixtures {
+	f := Fixtures{
+		BeeNames:    []string{"Beeatrice", "Bumblebeetle", "Beeyonce", "Buzz Aldrin", "Sting"},
+		Suggestions: []string{"Beelzebub", "Honeybunch", "Obee-Wan Kenobee"},
+		Users:       map[string]api.User{},
+		Platforms:   map[string]string{},
+		MCServers: map[string]api.MCServerStatus{
+			"mc.hypixel.net":                      mcServer("mc.hypixel.net", 25565, "Hypixel Network\\nSKYBLOCK | BEDWARS", "Requires MC 1.8 / 1.21", 41327, 200000),
+			"play.example.com:25566":              mcServer("play.example.com", 25566, "An Example Server", "Paper 1.21.4", 3, 20),
+			BedrockKey("geo.hivebedrock.network"): mcServer("geo.hivebedrock.network", 19132, "The Hive", "1.21.50", 12876, 100000),
+		},
+		GameServers: map[string]api.ServerStatus{
+			GameServerKey("minecraft", "mc.hypixel.net", "25565"): gameServer("mc.hypixel.net", 25565, "Hypixel Network", "world", 41327, 200000),
+			GameServerKey("valheim", "203.0.113.7", "2457"):       gameServer("203.0.113.7", 2457, "Viking Hideout", "Meadows", 4, 10),
+		},
+	}
+	f.AddAdmin("100000000000000001", "mock-admin")
+	return f
+}
+
+func mcServer(host string, port int, motd, version string, players, maxPlayers int) api.MCServerStatus {
+	return api.MCServerStatus{
+		Host:       host,
+		Port:       port,
+		Name:       host,
+		Motd:       motd,
+		Map:        "world",
+		NumPlayers: players,
+		MaxPlayers: maxPlayers,
+		Version:    version,
+		ServerType: "mock",
+	}
+}
+
+func gameServer(host string, port int, name, mapName string, players, maxPlayers int) api.ServerStatus {
+	return api.ServerStatus{
+		Host:       host,
+		Port:       port,
+		Name:       name,
+		MapName:    mapName,
+		NumPlayers: players,
+		MaxPlayers: maxPlayers,
+		QueryType:  "mock",
+	}
+}
+
+// clone returns a copy the server can change without touching the fixtures
+func (f Fixtures) clone() Fixtures {
+	return Fixtures{
+		BeeNames:    slices.Clone(f.BeeNames),
+		Suggestions: slices.Clone(f.Suggestions),
+		Users:       cloneMap(f.Users),
+		Platforms:   cloneMap(f.Platforms),
+		MCServers:   cloneMap(f.MCServers),
+		GameServers: cloneMap(f.GameServers),
+	}
+}
+
+// cloneMap clones m, returning an empty map if it's nil
+func cloneMap[K comparable, V any](m map[K]V) map[K]V {
+	if m == nil {
+		return map[K]V{}
+	}
+	return maps.Clone(m)
+}
+
+// defaultIcon 1x1 transparent PNG served as every server's icon
+var defaultIcon = []byte{
+	0x89, 0x50, 0x4e, 0x47, 0x0d, 0x0a, 0x1a, 0x0a, 0x00, 0x00, 0x00, 0x0d, 0x49, 0x48, 0x44, 0x52,
+	0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x01, 0x08, 0x06, 0x00, 0x00, 0x00, 0x1f, 0x15, 0xc4,
+	0x89, 0x00, 0x00, 0x00, 0x0d, 0x49, 0x44, 0x41, 0x54, 0x78, 0x9c, 0x63, 0x00, 0x01, 0x00, 0x00,
+	0x05, 0x00, 0x01, 0x0d, 0x0a, 0x2d, 0xb4, 0x00, 0x00, 0x00, 0x00, 0x49, 0x45, 0x4e, 0x44, 0xae,
+	0x42, 0x60, 0x82,
+}
@@ -0,0 +1,444 @@
+// Package apitest fake NeuralNexus API for development and tests.
+//
+// Server implements the bee name, suggestion, user, mcstatus and game server status endpoints in memory,
+// seeded with fixtures, and can inject latency, failures and malformed responses:
+//
+//	srv := httptest.NewServer(apitest.NewServer(apitest.DefaultFixtures()))
+//	defer srv.Close()
+//	client := api.NewClient(srv.URL, "")
+package apitest
+
+import (
+	"encoding/json"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/NeuralNexusDev/neuralnexus-discord-bot/src/api"
+)
+
+// Faults failures injected into the API's responses, the zero value injects none
+type Faults struct {
+	// Latency added before every response, a duration string like "500ms" in JSON
+	Latency time.Duration `json:"-"`
+	// ErrorRate fraction of requests, 0 to 1, failing with a 500
+	ErrorRate float64
+	// MalformedRate fraction of requests, 0 to 1, answered with a truncated JSON body
+	MalformedRate float64
+}
+
+// faultsJSON JSON form of Faults
+type faultsJSON struct {
+	Latency       string  `json:"latency"`
+	ErrorRate     float64 `json:"error_rate"`
+	MalformedRate float64 `json:"malformed_rate"`
+}
+
+func (f Faults) MarshalJSON() ([]byte, error) {
+	return json.Marshal(faultsJSON{Latency: f.Latency.String(), ErrorRate: f.ErrorRate, MalformedRate: f.MalformedRate})
+}
+
+func (f *Faults) UnmarshalJSON(data []byte) error {
+	var j faultsJSON
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+	*f = Faults{ErrorRate: j.ErrorRate, MalformedRate: j.MalformedRate}
+	if j.Latency != "" {
+		latency, err := time.ParseDuration(j.Latency)
+		if err != nil {
+			return err
+		}
+		f.Latency = latency
+	}
+	return nil
+}
+
+// Server fake NeuralNexus API, safe for concurrent use
+type Server struct {
+	// APIKey required as a bearer token by the endpoints that change data, if set
+	APIKey string
+
+	mu          sync.Mutex
+	fixtures    Fixtures
+	data        Fixtures
+	faults      Faults
+	failNext    []int
+	nextID      int
+	requests    atomic.Int64
+	requestSeq  atomic.Int64
+	mux         *http.ServeMux
+	adminRoutes *http.ServeMux
+}
+
+// NewServer returns a server seeded with the fixtures
+func NewServer(fixtures Fixtures) *Server {
+	s := &Server{fixtures: fixtures}
+	s.Reset()
+
+	s.mux = http.NewServeMux()
+	s.mux.HandleFunc("GET /bee-name-generator/name", s.getBeeName)
+	s.mux.HandleFunc("POST /bee-name-generator/name/{name}", s.authorized(s.uploadBeeName))
+	s.mux.HandleFunc("DELETE /bee-name-generator/name/{name}", s.authorized(s.deleteBeeName))
+	s.mux.HandleFunc("GET /bee-name-generator/suggestion/{amount}", s.getSuggestions)
+	s.mux.HandleFunc("POST /bee-name-generator/suggestion/{name}", s.submitSuggestion)
+	s.mux.HandleFunc("PUT /bee-name-generator/suggestion/{name}", s.authorized(s.acceptSuggestion))
+	s.mux.HandleFunc("DELETE /bee-name-generator/suggestion/{name}", s.authorized(s.rejectSuggestion))
+	s.mux.HandleFunc("GET /users/{id}", s.getUser)
+	s.mux.HandleFunc("PUT /users/{id}", s.authorized(s.updateUser))
+	s.mux.HandleFunc("GET /users/{id}/permissions", s.getPermissions)
+	s.mux.HandleFunc("GET /users/{platform}/{platformID}", s.getPlatformUser)
+	s.mux.HandleFunc("PUT /users/{platform}/{platformID}", s.authorized(s.updatePlatformUser))
+	s.mux.HandleFunc("GET /mcstatus/{host}", s.getMCStatus)
+	s.mux.HandleFunc("GET /mcstatus/icon/{host}", s.getMCIcon)
+	s.mux.HandleFunc("GET /game-server-status/{game}", s.getServerStatus)
+
+	s.adminRoutes = http.NewServeMux()
+	s.adminRoutes.HandleFunc("GET /_mock/faults", s.getFaults)
+	s.adminRoutes.HandleFunc("PUT /_mock/faults", s.putFaults)
+	s.adminRoutes.HandleFunc("POST /_mock/reset", s.postReset)
+	return s
+}
+
+// SetFaults replaces the injected faults
+func (s *Server) SetFaults(f Faults) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = f
+}
+
+// FailNext makes the next n requests fail with the status, after any failures queued before
+func (s *Server) FailNext(n, status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for range n {
+		s.failNext = append(s.failNext, status)
+	}
+}
+
+// Reset restores the fixtures and clears the injected faults
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data = s.fixtures.clone()
+	s.faults = Faults{}
+	s.failNext = nil
+}
+
+// Requests returns the number of API requests served, not counting the /_mock endpoints
+func (s *Server) Requests() int {
+	return int(s.requests.Load())
+}
+
+// BeeNames returns the current bee names
+func (s *Server) BeeNames() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.data.BeeNames)
+}
+
+// Suggestions returns the current bee name suggestions
+func (s *Server) Suggestions() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.data.Suggestions)
+}
+
+// ServeHTTP serves the API, injecting the configured faults
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("X-Request-ID", "mock-"+strconv.FormatInt(s.requestSeq.Add(1), 10))
+	if strings.HasPrefix(r.URL.Path, "/_mock/") {
+		s.adminRoutes.ServeHTTP(w, r)
+		return
+	}
+	s.requests.Add(1)
+
+	s.mu.Lock()
+	faults := s.faults
+	status := 0
+	if len(s.failNext) > 0 {
+		status, s.failNext = s.failNext[0], s.failNext[1:]
+	}
+	s.mu.Unlock()
+
+	if faults.Latency > 0 {
+		select {
+		case <-time.After(faults.Latency):
+		case <-r.Context().Done():
+			return
+		}
+	}
+	switch {
+	case status != 0:
+		writeProblem(w, status, "injected failure")
+	case faults.ErrorRate > 0 && rand.Float64() < faults.ErrorRate:
+		writeProblem(w, http.StatusInternalServerError, "injected failure")
+	case faults.MalformedRate > 0 && rand.Float64() < faults.MalformedRate:
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"detail": "truncat`))
+	default:
+		s.mux.ServeHTTP(w, r)
+	}
+}
+
+// authorized requires the API key, if the server has one
+func (s *Server) authorized(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if s.APIKey != "" && r.Header.Get("Authorization") != "Bearer "+s.APIKey {
+			writeProblem(w, http.StatusUnauthorized, "Invalid API key")
+			return
+		}
+		h(w, r)
+	}
+}
+
+func (s *Server) getBeeName(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.data.BeeNames) == 0 {
+		writeProblem(w, http.StatusNotFound, "No bee names")
+		return
+	}
+	writeJSON(w, http.StatusOK, api.BeeName{Name: s.data.BeeNames[rand.IntN(len(s.data.BeeNames))]})
+}
+
+func (s *Server) uploadBeeName(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !slices.Contains(s.data.BeeNames, name) {
+		s.data.BeeNames = append(s.data.BeeNames, name)
+	}
+	writeJSON(w, http.StatusOK, api.BeeName{Name: name})
+}
+
+func (s *Server) deleteBeeName(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := slices.Index(s.data.BeeNames, name)
+	if i < 0 {
+		writeProblem(w, http.StatusNotFound, "Bee name not found")
+		return
+	}
+	s.data.BeeNames = slices.Delete(s.data.BeeNames, i, i+1)
+	writeJSON(w, http.StatusOK, api.BeeName{Name: name})
+}
+
+func (s *Server) getSuggestions(w http.ResponseWriter, r *http.Request) {
+	amount, err := strconv.Atoi(r.PathValue("amount"))
+	if err != nil || amount < 1 {
+		writeProblem(w, http.StatusUnprocessableEntity, "amount must be a positive integer")
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	suggestions := s.data.Suggestions[:min(amount, len(s.data.Suggestions))]
+	writeJSON(w, http.StatusOK, api.BeeNameSuggestions{Suggestions: slices.Clone(suggestions)})
+}
+
+func (s *Server) submitSuggestion(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !slices.Contains(s.data.Suggestions, name) {
+		s.data.Suggestions = append(s.data.Suggestions, name)
+	}
+	writeJSON(w, http.StatusOK, api.BeeName{Name: name})
+}
+
+func (s *Server) acceptSuggestion(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := slices.Index(s.data.Suggestions, name)
+	if i < 0 {
+		writeProblem(w, http.StatusNotFound, "Suggestion not found")
+		return
+	}
+	s.data.Suggestions = slices.Delete(s.data.Suggestions, i, i+1)
+	if !slices.Contains(s.data.BeeNames, name) {
+		s.data.BeeNames = append(s.data.BeeNames, name)
+	}
+	writeJSON(w, http.StatusOK, api.BeeName{Name: name})
+}
+
+func (s *Server) rejectSuggestion(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := slices.Index(s.data.Suggestions, name)
+	if i < 0 {
+		writeProblem(w, http.StatusNotFound, "Suggestion not found")
+		return
+	}
+	s.data.Suggestions = slices.Delete(s.data.Suggestions, i, i+1)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) getUser(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	user, ok := s.data.Users[r.PathValue("id")]
+	if !ok {
+		writeProblem(w, http.StatusNotFound, "User not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, user)
+}
+
+func (s *Server) updateUser(w http.ResponseWriter, r *http.Request) {
+	var update api.User
+	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+		writeProblem(w, http.StatusUnprocessableEntity, "Invalid user: "+err.Error())
+		return
+	}
+	id := r.PathValue("id")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	user, ok := s.data.Users[id]
+	if !ok {
+		writeProblem(w, http.StatusNotFound, "User not found")
+		return
+	}
+	if update.Username != "" {
+		user.Username = update.Username
+	}
+	if update.Roles != nil {
+		user.Roles = update.Roles
+	}
+	if update.Permissions != nil {
+		user.Permissions = update.Permissions
+	}
+	user.UpdatedAt = time.Now().UTC()
+	s.data.Users[id] = user
+	writeJSON(w, http.StatusOK, user)
+}
+
+func (s *Server) getPermissions(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	user, ok := s.data.Users[r.PathValue("id")]
+	if !ok {
+		writeProblem(w, http.StatusNotFound, "User not found")
+		return
+	}
+	permissions := user.Permissions
+	if permissions == nil {
+		permissions = []string{}
+	}
+	writeJSON(w, http.StatusOK, permissions)
+}
+
+func (s *Server) getPlatformUser(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	user, ok := s.data.Users[s.data.Platforms[PlatformKey(r.PathValue("platform"), r.PathValue("platformID"))]]
+	if !ok {
+		writeProblem(w, http.StatusNotFound, "User not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, user)
+}
+
+// updatePlatformUser creates or updates the user linked to the platform account, taking the username from the body
+func (s *Server) updatePlatformUser(w http.ResponseWriter, r *http.Request) {
+	var account struct {
+		Username string `json:"username"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&account); err != nil {
+		writeProblem(w, http.StatusUnprocessableEntity, "Invalid platform data: "+err.Error())
+		return
+	}
+	key := PlatformKey(r.PathValue("platform"), r.PathValue("platformID"))
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id, ok := s.data.Platforms[key]
+	if !ok {
+		s.nextID++
+		id = "mock-user-" + strconv.Itoa(s.nextID)
+		s.data.Platforms[key] = id
+	}
+	user := s.data.Users[id]
+	user.UserID = id
+	if account.Username != "" {
+		user.Username = account.Username
+	}
+	user.UpdatedAt = time.Now().UTC()
+	s.data.Users[id] = user
+	writeJSON(w, http.StatusOK, user)
+}
+
+func (s *Server) getMCStatus(w http.ResponseWriter, r *http.Request) {
+	key := r.PathValue("host")
+	if r.URL.Query().Get("bedrock") == "true" {
+		key = BedrockKey(key)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	status, ok := s.data.MCServers[key]
+	if !ok {
+		writeProblem(w, http.StatusNotFound, "Server not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, status)
+}
+
+func (s *Server) getMCIcon(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "image/png")
+	_, _ = w.Write(defaultIcon)
+}
+
+func (s *Server) getServerStatus(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if query.Get("host") == "" || query.Get("port") == "" {
+		writeProblem(w, http.StatusUnprocessableEntity, "host and port are required")
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	status, ok := s.data.GameServers[GameServerKey(r.PathValue("game"), query.Get("host"), query.Get("port"))]
+	if !ok {
+		writeProblem(w, http.StatusNotFound, "Server not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, status)
+}
+
+func (s *Server) getFaults(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeJSON(w, http.StatusOK, s.faults)
+}
+
+func (s *Server) putFaults(w http.ResponseWriter, r *http.Request) {
+	var f Faults
+	if err := json.NewDecoder(r.Body).Decode(&f); err != nil {
+		writeProblem(w, http.StatusBadRequest, "Invalid faults: "+err.Error())
+		return
+	}
+	s.SetFaults(f)
+	writeJSON(w, http.StatusOK, f)
+}
+
+func (s *Server) postReset(w http.ResponseWriter, r *http.Request) {
+	s.Reset()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeJSON writes v as the JSON response
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeProblem writes an error response with a FastAPI style detail
+func writeProblem(w http.ResponseWriter, status int, detail string) {
+	writeJSON(w, status, map[string]string{"detail": detail})
+}
@@ -1,8 +1,7 @@
 package api
 
 import (
-	"encoding/json"
-	"errors"
+	"context"
 	"net/http"
 )
 
@@ -17,19 +16,9 @@ type BeeNameSuggestions struct {
 }
 
 // GetBeeName fetches a bee name from the NeuralNexus API
-func GetBeeName() (*BeeName, error) {
-	resp, err := APIRequest("GET", "/bee-name-generator/name", nil)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, errors.New("error fetching bee name")
-	}
-
-	var name BeeName
-	err = json.NewDecoder(resp.Body).Decode(&name)
+func (c *Client) GetBeeName(ctx context.Context) (*BeeName, error) {
+	ctx = withRoute(ctx, "/bee-name-generator/name")
+	name, err := Do[BeeName](ctx, c, "GET", "/bee-name-generator/name", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -37,46 +26,29 @@ func GetBeeName() (*BeeName, error) {
 }
 
 // UploadBeeName uploads a bee name to the NeuralNexus API
-func UploadBeeName(name string) error {
-	resp, err := APIRequest("POST", "/bee-name-generator/name/"+name, nil)
-	if err != nil {
+func (c *Client) UploadBeeName(ctx context.Context, name string) error {
+	if err := validateName("bee name", name); err != nil {
 		return err
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return errors.New("error uploading bee name")
-	}
-	return nil
+	ctx = withRoute(ctx, "/bee-name-generator/name/{name}")
+	_, err := Do[NoContent](ctx, c, "POST", apiPath("bee-name-generator", "name", name), nil)
+	return err
 }
 
 // DeleteBeeName deletes a bee name from the NeuralNexus API
-func DeleteBeeName(name string) error {
-	resp, err := APIRequest("DELETE", "/bee-name-generator/name/"+name, nil)
-	if err != nil {
+func (c *Client) DeleteBeeName(ctx context.Context, name string) error {
+	if err := validateName("bee name", name); err != nil {
 		return err
 	}
-
-	if resp.StatusCode != http.StatusOK {
-		return errors.New("error deleting bee name")
-	}
-	return nil
+	ctx = withRoute(ctx, "/bee-name-generator/name/{name}")
+	_, err := Do[NoContent](ctx, c, "DELETE", apiPath("bee-name-generator", "name", name), nil)
+	return err
 }
 
 // GetBeeNameSuggestions fetches bee name suggestions from the NeuralNexus API
-func GetBeeNameSuggestions() (*BeeNameSuggestions, error) {
-	resp, err := APIRequest("GET", "/bee-name-generator/suggestion/1", nil)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, errors.New("error fetching bee name suggestions")
-	}
-
-	var suggestions BeeNameSuggestions
-	err = json.NewDecoder(resp.Body).Decode(&suggestions)
+func (c *Client) GetBeeNameSuggestions(ctx context.Context) (*BeeNameSuggestions, error) {
+	ctx = withRoute(ctx, "/bee-name-generator/suggestion/{amount}")
+	suggestions, err := Do[BeeNameSuggestions](ctx, c, "GET", "/bee-name-generator/suggestion/1", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -84,42 +56,31 @@ func GetBeeNameSuggestions() (*BeeNameSuggestions, error) {
 }
 
 // SubmitBeeNameSuggestion submits a bee name suggestion to the NeuralNexus API
-func SubmitBeeNameSuggestion(name string) error {
-	resp, err := APIRequest("POST", "/bee-name-generator/suggestion/"+name, nil)
-	if err != nil {
+func (c *Client) SubmitBeeNameSuggestion(ctx context.Context, name string) error {
+	if err := validateName("bee name", name); err != nil {
 		return err
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return errors.New("error submitting bee name suggestion")
-	}
-	return nil
+	ctx = withRoute(ctx, "/bee-name-generator/suggestion/{name}")
+	_, err := Do[NoContent](ctx, c, "POST", apiPath("bee-name-generator", "suggestion", name), nil)
+	return err
 }
 
 // AcceptBeeNameSuggestion accepts a bee name suggestion on the NeuralNexus API
-func AcceptBeeNameSuggestion(name string) error {
-	resp, err := APIRequest("PUT", "/bee-name-generator/suggestion/"+name, nil)
-	if err != nil {
+func (c *Client) AcceptBeeNameSuggestion(ctx context.Context, name string) error {
+	if err := validateName("bee name", name); err != nil {
 		return err
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return errors.New("error accepting bee name suggestion")
-	}
-	return nil
+	ctx = withRoute(ctx, "/bee-name-generator/suggestion/{name}")
+	_, err := Do[NoContent](ctx, c, "PUT", apiPath("bee-name-generator", "suggestion", name), nil)
+	return err
 }
 
 // RejectBeeNameSuggestion rejects a bee name suggestion on the NeuralNexus API
-func RejectBeeNameSuggestion(name string) error {
-	resp, err := APIRequest("DELETE", "/bee-name-generator/suggestion/"+name, nil)
-	if err != nil {
+func (c *Client) RejectBeeNameSuggestion(ctx context.Context, name string) error {
+	if err := validateName("bee name", name); err != nil {
 		return err
 	}
-
-	if resp.StatusCode != http.StatusNoContent {
-		return errors.New("error rejecting bee name suggestion")
-	}
-	return nil
+	ctx = withRoute(ctx, "/bee-name-generator/suggestion/{name}")
+	_, err := Do[NoContent](ctx, c, "DELETE", apiPath("bee-name-generator", "suggestion", name), nil, http.StatusNoContent)
+	return err
 }
@@ -0,0 +1,165 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Circuit breaker defaults of clients created by NewClient
+const (
+	DefaultBreakerThreshold = 5
+	DefaultBreakerCooldown  = 30 * time.Second
+)
+
+// ErrCircuitOpen the request wasn't sent because the API kept failing, it also matches ErrUnavailable
+var ErrCircuitOpen = errors.New("NeuralNexus API is currently unavailable")
+
+// BreakerState circuit breaker state
+type BreakerState int
+
+const (
+	// BreakerClosed requests are sent
+	BreakerClosed BreakerState = iota
+	// BreakerOpen requests fail without being sent until the cooldown ends
+	BreakerOpen
+	// BreakerHalfOpen one probe request is sent, closing the breaker if it succeeds
+	BreakerHalfOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	}
+	return "unknown"
+}
+
+// Breaker circuit breaker tripping after consecutive failed requests.
+// Once its cooldown ends it lets one probe request through, closing if it succeeds and reopening if it fails.
+type Breaker struct {
+	threshold int
+	cooldown  time.Duration
+	mu        sync.Mutex
+	state     BreakerState
+	failures  int
+	openedAt  time.Time
+	probing   bool
+	trips     uint64
+}
+
+// BreakerStats snapshot of a circuit breaker, for health checks
+type BreakerStats struct {
+	State string `json:"state"`
+	// Failures consecutive failed requests
+	Failures int `json:"failures"`
+	// OpenedAt when the breaker last tripped
+	OpenedAt time.Time `json:"opened_at,omitzero"`
+	// RetryAt when the next probe request is allowed, while open
+	RetryAt time.Time `json:"retry_at,omitzero"`
+	// Trips times the breaker tripped
+	Trips uint64 `json:"trips"`
+}
+
+// NewBreaker returns a breaker tripping after threshold consecutive failures and probing after cooldown
+func NewBreaker(threshold int, cooldown time.Duration) *Breaker {
+	return &Breaker{threshold: threshold, cooldown: cooldown}
+}
+
+// Allow checks whether a request may be sent, returning ErrCircuitOpen if not.
+// Allowed requests must be reported with Record.
+func (b *Breaker) Allow() error {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.currentState() {
+	case BreakerOpen:
+		return ErrCircuitOpen
+	case BreakerHalfOpen:
+		if b.probing {
+			return ErrCircuitOpen
+		}
+		b.state = BreakerHalfOpen
+		b.probing = true
+	}
+	return nil
+}
+
+// Record reports the outcome of an allowed request
+func (b *Breaker) Record(failed bool) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !failed {
+		b.state, b.failures, b.probing = BreakerClosed, 0, false
+		return
+	}
+	b.failures++
+	if b.probing || (b.state == BreakerClosed && b.failures >= b.threshold) {
+		b.state, b.openedAt, b.probing = BreakerOpen, time.Now(), false
+		b.trips++
+		BreakerTrips.Inc()
+	}
+}
+
+// Cancel reports that an allowed request was abandoned before its outcome was known
+func (b *Breaker) Cancel() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+}
+
+// State returns the breaker's state, BreakerClosed for a nil breaker
+func (b *Breaker) State() BreakerState {
+	if b == nil {
+		return BreakerClosed
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.currentState()
+}
+
+// Stats returns a snapshot of the breaker
+func (b *Breaker) Stats() BreakerStats {
+	if b == nil {
+		return BreakerStats{State: BreakerClosed.String()}
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	stats := BreakerStats{
+		State:    b.currentState().String(),
+		Failures: b.failures,
+		OpenedAt: b.openedAt,
+		Trips:    b.trips,
+	}
+	if b.state == BreakerOpen {
+		stats.RetryAt = b.openedAt.Add(b.cooldown)
+	}
+	return stats
+}
+
+// currentState returns the state, half-open once an open breaker's cooldown ended
+func (b *Breaker) currentState() BreakerState {
+	if b.state == BreakerOpen && time.Since(b.openedAt) >= b.cooldown {
+		return BreakerHalfOpen
+	}
+	return b.state
+}
+
+// breakerFailure checks whether the attempt counts against the breaker: the API couldn't be reached,
+// was too slow or failed to handle the request. Attempts the caller abandoned are never passed in.
+func breakerFailure(resp *http.Response, err error) bool {
+	return err != nil || resp.StatusCode >= http.StatusInternalServerError
+}
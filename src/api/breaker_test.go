@@ -0,0 +1,154 @@
+package api_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/NeuralNexusDev/neuralnexus-discord-bot/src/api"
+	"github.com/NeuralNexusDev/neuralnexus-discord-bot/src/api/apitest"
+)
+
+func TestBreakerTripsAndFailsFast(t *testing.T) {
+	c, mock := newTestClient(t)
+	c.Retry = api.RetryPolicy{}
+	c.Breaker = api.NewBreaker(2, time.Hour)
+	mock.FailNext(2, http.StatusInternalServerError)
+
+	for range 2 {
+		if _, err := c.GetBeeName(context.Background()); !errors.Is(err, api.ErrUnavailable) {
+			t.Fatalf("GetBeeName() error = %v, want ErrUnavailable", err)
+		}
+	}
+	if state := c.Breaker.State(); state != api.BreakerOpen {
+		t.Fatalf("state = %v, want open", state)
+	}
+
+	_, err := c.GetBeeName(context.Background())
+	if !errors.Is(err, api.ErrCircuitOpen) || !errors.Is(err, api.ErrUnavailable) {
+		t.Errorf("GetBeeName() error = %v, want ErrCircuitOpen matching ErrUnavailable", err)
+	}
+	if n := mock.Requests(); n != 2 {
+		t.Errorf("requests = %d, want 2", n)
+	}
+	if stats := c.Breaker.Stats(); stats.Trips != 1 || stats.RetryAt.IsZero() {
+		t.Errorf("stats = %+v, want 1 trip and a retry time", stats)
+	}
+}
+
+func TestBreakerOpenIsNotRetried(t *testing.T) {
+	c, mock := newTestClient(t)
+	c.Breaker = api.NewBreaker(1, time.Hour)
+	mock.FailNext(1, http.StatusInternalServerError)
+
+	// The first attempt trips the breaker, the retry is rejected without being sent
+	_, err := c.GetBeeName(context.Background())
+	if !errors.Is(err, api.ErrCircuitOpen) {
+		t.Fatalf("GetBeeName() error = %v, want ErrCircuitOpen", err)
+	}
+
+	retries, exhausted := api.RequestRetries.Value(), api.RetriesExhausted.Value()
+	_, err = c.GetBeeName(context.Background())
+	if !errors.Is(err, api.ErrCircuitOpen) {
+		t.Fatalf("GetBeeName() error = %v, want ErrCircuitOpen", err)
+	}
+	if n := mock.Requests(); n != 1 {
+		t.Errorf("requests = %d, want 1", n)
+	}
+	if api.RequestRetries.Value() != retries || api.RetriesExhausted.Value() != exhausted {
+		t.Error("a request rejected by the open breaker was counted as retried")
+	}
+}
+
+func TestBreakerRecoversAfterProbe(t *testing.T) {
+	c, mock := newTestClient(t)
+	c.Retry = api.RetryPolicy{}
+	c.Breaker = api.NewBreaker(1, 20*time.Millisecond)
+	mock.FailNext(1, http.StatusInternalServerError)
+
+	_, _ = c.GetBeeName(context.Background())
+	if state := c.Breaker.State(); state != api.BreakerOpen {
+		t.Fatalf("state = %v, want open", state)
+	}
+	time.Sleep(30 * time.Millisecond)
+	if state := c.Breaker.State(); state != api.BreakerHalfOpen {
+		t.Fatalf("state = %v, want half-open after the cooldown", state)
+	}
+
+	_, err := c.GetBeeName(context.Background())
+	if err != nil {
+		t.Fatalf("GetBeeName() error = %v", err)
+	}
+	if state := c.Breaker.State(); state != api.BreakerClosed {
+		t.Errorf("state = %v, want closed after a successful probe", state)
+	}
+}
+
+func TestBreakerReopensOnFailedProbe(t *testing.T) {
+	c, mock := newTestClient(t)
+	c.Retry = api.RetryPolicy{}
+	c.Breaker = api.NewBreaker(1, 20*time.Millisecond)
+	mock.FailNext(2, http.StatusInternalServerError)
+
+	_, _ = c.GetBeeName(context.Background())
+	time.Sleep(30 * time.Millisecond)
+	_, err := c.GetBeeName(context.Background())
+	if !errors.Is(err, api.ErrUnavailable) || errors.Is(err, api.ErrCircuitOpen) {
+		t.Fatalf("GetBeeName() error = %v, want the probe's ErrUnavailable", err)
+	}
+	if state := c.Breaker.State(); state != api.BreakerOpen {
+		t.Errorf("state = %v, want open after a failed probe", state)
+	}
+	if stats := c.Breaker.Stats(); stats.Trips != 2 {
+		t.Errorf("trips = %d, want 2", stats.Trips)
+	}
+}
+
+func TestBreakerIgnoresClientErrors(t *testing.T) {
+	c, mock := newTestClient(t)
+	c.Breaker = api.NewBreaker(1, time.Hour)
+	mock.FailNext(3, http.StatusNotFound)
+
+	for range 3 {
+		if _, err := c.GetBeeName(context.Background()); !errors.Is(err, api.ErrNotFound) {
+			t.Fatalf("GetBeeName() error = %v, want ErrNotFound", err)
+		}
+	}
+	if state := c.Breaker.State(); state != api.BreakerClosed {
+		t.Errorf("state = %v, want closed", state)
+	}
+}
+
+func TestBreakerUnderInjectedFailures(t *testing.T) {
+	c, mock := newTestClient(t)
+	c.Breaker = api.NewBreaker(3, time.Hour)
+	mock.SetFaults(apitest.Faults{ErrorRate: 1})
+
+	for range 10 {
+		_, _ = c.GetBeeName(context.Background())
+	}
+	if state := c.Breaker.State(); state != api.BreakerOpen {
+		t.Errorf("state = %v, want open", state)
+	}
+	if n := mock.Requests(); n != 3 {
+		t.Errorf("requests = %d, want the breaker to stop them after 3", n)
+	}
+}
+
+func TestBreakerIgnoresCallerDeadlines(t *testing.T) {
+	c, mock := newTestClient(t)
+	c.Retry = api.RetryPolicy{}
+	c.Breaker = api.NewBreaker(1, time.Hour)
+	mock.SetFaults(apitest.Faults{Latency: time.Second})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := c.GetBeeName(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("GetBeeName() error = %v, want DeadlineExceeded", err)
+	}
+	if state := c.Breaker.State(); state != api.BreakerClosed {
+		t.Errorf("state = %v, want closed", state)
+	}
+}
@@ -0,0 +1,150 @@
+package api
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/NeuralNexusDev/neuralnexus-discord-bot/src/metrics"
+)
+
+// Status cache defaults of clients created by NewClient
+const (
+	DefaultStatusCacheTTL  = 30 * time.Second
+	DefaultStatusCacheSize = 512
+)
+
+// MaxStaleAge age up to which cached responses are still served while the API is unavailable
+const MaxStaleAge = 10 * time.Minute
+
+// MinRefreshAge age a cached response must reach before a refresh bypasses it
+const MinRefreshAge = 5 * time.Second
+
+// FetchTimeout time a shared fetch may take, it outlives the callers waiting on it
+const FetchTimeout = 10 * time.Second
+
+// noCacheKey context key of NoCache
+type noCacheKey struct{}
+
+// NoCache returns a context whose cached lookups fetch fresh responses, unless the cached ones are younger than MinRefreshAge
+func NoCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noCacheKey{}, true)
+}
+
+// Cache TTL cache of API responses holding at most its size in entries, evicting the least recently used.
+// Concurrent lookups of the same key share one fetch, and expired responses up to MaxStaleAge are served
+// while the API is unavailable.
+type Cache[V any] struct {
+	ttl     time.Duration
+	size    int
+	stats   *metrics.CacheStats
+	mu      sync.Mutex
+	lru     *list.List
+	entries map[string]*list.Element
+	calls   map[string]*cacheCall[V]
+}
+
+// cacheEntry cached response
+type cacheEntry[V any] struct {
+	key       string
+	value     V
+	fetchedAt time.Time
+}
+
+// cacheCall fetch in flight, done is closed once value and err are set
+type cacheCall[V any] struct {
+	done      chan struct{}
+	value     V
+	fetchedAt time.Time
+	err       error
+}
+
+// NewCache returns a cache keeping responses for ttl, counting hits and misses in stats
+func NewCache[V any](ttl time.Duration, size int, stats *metrics.CacheStats) *Cache[V] {
+	return &Cache[V]{
+		ttl:     ttl,
+		size:    size,
+		stats:   stats,
+		lru:     list.New(),
+		entries: map[string]*list.Element{},
+		calls:   map[string]*cacheCall[V]{},
+	}
+}
+
+// Get returns the cached response for key and when it was fetched, calling fetch if it's missing or expired.
+// The fetch isn't cancelled with ctx, since other callers may be waiting on it, it's bounded by FetchTimeout instead.
+// Errors aren't cached.
+func (c *Cache[V]) Get(ctx context.Context, key string, fetch func(ctx context.Context) (V, error)) (V, time.Time, error) {
+	if c == nil {
+		v, err := fetch(ctx)
+		return v, time.Now(), err
+	}
+	maxAge := c.ttl
+	if refresh, _ := ctx.Value(noCacheKey{}).(bool); refresh {
+		maxAge = min(maxAge, MinRefreshAge)
+	}
+
+	c.mu.Lock()
+	if el, ok := c.entries[key]; ok {
+		entry := el.Value.(*cacheEntry[V])
+		if time.Since(entry.fetchedAt) < maxAge {
+			c.lru.MoveToFront(el)
+			c.mu.Unlock()
+			c.stats.Hits.Inc()
+			return entry.value, entry.fetchedAt, nil
+		}
+	}
+	c.stats.Misses.Inc()
+	call, ok := c.calls[key]
+	if !ok {
+		call = &cacheCall[V]{done: make(chan struct{})}
+		c.calls[key] = call
+		go c.fetch(context.WithoutCancel(ctx), key, call, fetch)
+	}
+	c.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.value, call.fetchedAt, call.err
+	case <-ctx.Done():
+		var zero V
+		return zero, time.Time{}, ctx.Err()
+	}
+}
+
+// fetch runs the call, caching its response if it succeeds
+func (c *Cache[V]) fetch(ctx context.Context, key string, call *cacheCall[V], fetch func(ctx context.Context) (V, error)) {
+	ctx, cancel := context.WithTimeout(ctx, FetchTimeout)
+	defer cancel()
+	call.value, call.err = fetch(ctx)
+	call.fetchedAt = time.Now()
+
+	c.mu.Lock()
+	delete(c.calls, key)
+	if call.err == nil {
+		c.set(key, call.value, call.fetchedAt)
+	} else if el, ok := c.entries[key]; ok && errors.Is(call.err, ErrUnavailable) {
+		if entry := el.Value.(*cacheEntry[V]); time.Since(entry.fetchedAt) < MaxStaleAge {
+			call.value, call.fetchedAt, call.err = entry.value, entry.fetchedAt, nil
+		}
+	}
+	c.mu.Unlock()
+	close(call.done)
+}
+
+// set caches the response, evicting the least recently used ones past the size
+func (c *Cache[V]) set(key string, value V, fetchedAt time.Time) {
+	if el, ok := c.entries[key]; ok {
+		el.Value = &cacheEntry[V]{key: key, value: value, fetchedAt: fetchedAt}
+		c.lru.MoveToFront(el)
+		return
+	}
+	c.entries[key] = c.lru.PushFront(&cacheEntry[V]{key: key, value: value, fetchedAt: fetchedAt})
+	for c.size > 0 && c.lru.Len() > c.size {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry[V]).key)
+	}
+}
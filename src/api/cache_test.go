@@ -0,0 +1,199 @@
+package api_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/NeuralNexusDev/neuralnexus-discord-bot/src/api"
+	"github.com/NeuralNexusDev/neuralnexus-discord-bot/src/api/apitest"
+	"github.com/NeuralNexusDev/neuralnexus-discord-bot/src/metrics"
+)
+
+// newTestCache returns a status cache with unpublished stats
+func newTestCache(ttl time.Duration, size int) *api.Cache[*api.MCServerStatus] {
+	return api.NewCache[*api.MCServerStatus](ttl, size, &metrics.CacheStats{Hits: &metrics.Counter{}, Misses: &metrics.Counter{}})
+}
+
+func TestCacheServesRepeatLookups(t *testing.T) {
+	c, mock := newTestClient(t)
+	c.MCStatusCache = newTestCache(time.Minute, 10)
+
+	first, err := c.GetMCServerStatus(context.Background(), "mc.hypixel.net", false)
+	if err != nil {
+		t.Fatalf("GetMCServerStatus() error = %v", err)
+	}
+	second, err := c.GetMCServerStatus(context.Background(), "mc.hypixel.net", false)
+	if err != nil {
+		t.Fatalf("GetMCServerStatus() error = %v", err)
+	}
+	if n := mock.Requests(); n != 1 {
+		t.Errorf("requests = %d, want 1", n)
+	}
+	if !first.FetchedAt.Equal(second.FetchedAt) {
+		t.Errorf("FetchedAt = %v, want the cached %v", second.FetchedAt, first.FetchedAt)
+	}
+
+	// Java and Bedrock Edition statuses are cached separately
+	_, err = c.GetMCServerStatus(context.Background(), "geo.hivebedrock.network", true)
+	if err != nil {
+		t.Fatalf("GetMCServerStatus() error = %v", err)
+	}
+	if n := mock.Requests(); n != 2 {
+		t.Errorf("requests = %d, want 2", n)
+	}
+}
+
+func TestCacheExpires(t *testing.T) {
+	c, mock := newTestClient(t)
+	c.MCStatusCache = newTestCache(10*time.Millisecond, 10)
+
+	_, _ = c.GetMCServerStatus(context.Background(), "mc.hypixel.net", false)
+	time.Sleep(20 * time.Millisecond)
+	_, err := c.GetMCServerStatus(context.Background(), "mc.hypixel.net", false)
+	if err != nil {
+		t.Fatalf("GetMCServerStatus() error = %v", err)
+	}
+	if n := mock.Requests(); n != 2 {
+		t.Errorf("requests = %d, want 2", n)
+	}
+}
+
+func TestCacheNoCacheKeepsFreshEntries(t *testing.T) {
+	c, mock := newTestClient(t)
+	c.MCStatusCache = newTestCache(time.Minute, 10)
+
+	_, _ = c.GetMCServerStatus(context.Background(), "mc.hypixel.net", false)
+	_, err := c.GetMCServerStatus(api.NoCache(context.Background()), "mc.hypixel.net", false)
+	if err != nil {
+		t.Fatalf("GetMCServerStatus() error = %v", err)
+	}
+	if n := mock.Requests(); n != 1 {
+		t.Errorf("requests = %d, want entries younger than MinRefreshAge to be served", n)
+	}
+}
+
+func TestCacheDoesNotCacheErrors(t *testing.T) {
+	c, mock := newTestClient(t)
+	c.MCStatusCache = newTestCache(time.Minute, 10)
+	mock.FailNext(1, http.StatusNotFound)
+
+	_, err := c.GetMCServerStatus(context.Background(), "mc.hypixel.net", false)
+	if !errors.Is(err, api.ErrNotFound) {
+		t.Fatalf("GetMCServerStatus() error = %v, want ErrNotFound", err)
+	}
+	_, err = c.GetMCServerStatus(context.Background(), "mc.hypixel.net", false)
+	if err != nil {
+		t.Fatalf("GetMCServerStatus() error = %v", err)
+	}
+	if n := mock.Requests(); n != 2 {
+		t.Errorf("requests = %d, want 2", n)
+	}
+}
+
+func TestCacheSharesConcurrentFetches(t *testing.T) {
+	c, mock := newTestClient(t)
+	c.MCStatusCache = newTestCache(time.Minute, 10)
+	mock.SetFaults(apitest.Faults{Latency: 50 * time.Millisecond})
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := c.GetMCServerStatus(context.Background(), "mc.hypixel.net", false)
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Errorf("GetMCServerStatus() error = %v", err)
+		}
+	}
+	if n := mock.Requests(); n != 1 {
+		t.Errorf("requests = %d, want 1", n)
+	}
+}
+
+func TestCacheFetchOutlivesCancelledCaller(t *testing.T) {
+	c, mock := newTestClient(t)
+	c.MCStatusCache = newTestCache(time.Minute, 10)
+	mock.SetFaults(apitest.Faults{Latency: 100 * time.Millisecond})
+
+	first := make(chan error, 1)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		_, err := c.GetMCServerStatus(ctx, "mc.hypixel.net", false)
+		first <- err
+	}()
+	time.Sleep(5 * time.Millisecond)
+	_, err := c.GetMCServerStatus(context.Background(), "mc.hypixel.net", false)
+	if err != nil {
+		t.Fatalf("GetMCServerStatus() error = %v, want the shared fetch to survive the first caller", err)
+	}
+	if err := <-first; !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("first GetMCServerStatus() error = %v, want its own deadline", err)
+	}
+	if n := mock.Requests(); n != 1 {
+		t.Errorf("requests = %d, want 1", n)
+	}
+}
+
+func TestCacheServesStaleWhileUnavailable(t *testing.T) {
+	c, mock := newTestClient(t)
+	c.Retry = api.RetryPolicy{}
+	c.MCStatusCache = newTestCache(10*time.Millisecond, 10)
+
+	fresh, err := c.GetMCServerStatus(context.Background(), "mc.hypixel.net", false)
+	if err != nil {
+		t.Fatalf("GetMCServerStatus() error = %v", err)
+	}
+	time.Sleep(20 * time.Millisecond)
+	mock.FailNext(1, http.StatusServiceUnavailable)
+	stale, err := c.GetMCServerStatus(context.Background(), "mc.hypixel.net", false)
+	if err != nil {
+		t.Fatalf("GetMCServerStatus() error = %v, want the stale status", err)
+	}
+	if !stale.FetchedAt.Equal(fresh.FetchedAt) {
+		t.Errorf("FetchedAt = %v, want the stale %v", stale.FetchedAt, fresh.FetchedAt)
+	}
+
+	// Other errors aren't hidden by stale entries
+	time.Sleep(20 * time.Millisecond)
+	mock.FailNext(1, http.StatusNotFound)
+	_, err = c.GetMCServerStatus(context.Background(), "mc.hypixel.net", false)
+	if !errors.Is(err, api.ErrNotFound) {
+		t.Errorf("GetMCServerStatus() error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	cache := newTestCache(time.Minute, 2)
+	fetches := map[string]int{}
+	get := func(key string) {
+		_, _, err := cache.Get(context.Background(), key, func(context.Context) (*api.MCServerStatus, error) {
+			fetches[key]++
+			return &api.MCServerStatus{Host: key}, nil
+		})
+		if err != nil {
+			t.Fatalf("Get(%q) error = %v", key, err)
+		}
+	}
+
+	get("a")
+	get("b")
+	get("a")
+	get("c") // evicts b, the least recently used
+	get("a")
+	get("b")
+	if fetches["a"] != 1 || fetches["b"] != 2 || fetches["c"] != 1 {
+		t.Errorf("fetches = %v, want a:1 b:2 c:1", fetches)
+	}
+}
@@ -0,0 +1,95 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DefaultTimeout request timeout of clients created by NewClient
+const DefaultTimeout = 10 * time.Second
+
+// DefaultUserAgent user agent of clients created by NewClient
+const DefaultUserAgent = "NeuralNexus-Discord-Bot"
+
+// Client NeuralNexus API client
+type Client struct {
+	// BaseURL API base URL, e.g. https://api.neuralnexus.dev/api/v1
+	BaseURL string
+	// APIKey sent as a bearer token, if set
+	APIKey     string
+	UserAgent  string
+	HTTPClient *http.Client
+	// Retry retry policy of read-only requests, the zero value doesn't retry
+	Retry RetryPolicy
+	// Breaker circuit breaker failing requests fast while the API is down, requests always go out if it's nil
+	Breaker *Breaker
+	// MCStatusCache Minecraft server status cache, lookups aren't cached if it's nil
+	MCStatusCache *Cache[*MCServerStatus]
+	// ServerStatusCache game server status cache, lookups aren't cached if it's nil
+	ServerStatusCache *Cache[*ServerStatus]
+}
+
+// NewClient returns a client for the API at baseURL with the default user agent, timeout, retry policy, circuit breaker and status caches
+func NewClient(baseURL, apiKey string) *Client {
+	return &Client{
+		BaseURL:           strings.TrimSuffix(baseURL, "/"),
+		APIKey:            apiKey,
+		UserAgent:         DefaultUserAgent,
+		HTTPClient:        &http.Client{Timeout: DefaultTimeout},
+		Retry:             DefaultRetryPolicy,
+		Breaker:           NewBreaker(DefaultBreakerThreshold, DefaultBreakerCooldown),
+		MCStatusCache:     NewCache[*MCServerStatus](DefaultStatusCacheTTL, DefaultStatusCacheSize, MCStatusCacheStats),
+		ServerStatusCache: NewCache[*ServerStatus](DefaultStatusCacheTTL, DefaultStatusCacheSize, ServerStatusCacheStats),
+	}
+}
+
+// apiPath joins the segments into an endpoint path, escaping each of them
+func apiPath(segments ...string) string {
+	var sb strings.Builder
+	for _, segment := range segments {
+		sb.WriteString("/" + url.PathEscape(segment))
+	}
+	return sb.String()
+}
+
+// routeKey context key of withRoute
+type routeKey struct{}
+
+// withRoute returns a context naming the endpoint's route template, like "/users/{id}", in request spans.
+// Spans record the template rather than the URL, which holds user input.
+func withRoute(ctx context.Context, route string) context.Context {
+	return context.WithValue(ctx, routeKey{}, route)
+}
+
+// Request sends a request to the endpoint, encoding body as JSON if it isn't nil.
+// Idempotent requests are retried after transient failures as the client's retry policy allows.
+func (c *Client) Request(ctx context.Context, method, endpoint string, body interface{}) (*http.Response, error) {
+	var payload []byte
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		payload = b
+	}
+
+	return c.do(ctx, method, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, method, c.BaseURL+endpoint, bytes.NewReader(payload))
+		if err != nil {
+			return nil, err
+		}
+		if c.APIKey != "" {
+			req.Header.Set("Authorization", "Bearer "+c.APIKey)
+		}
+		if c.UserAgent != "" {
+			req.Header.Set("User-Agent", c.UserAgent)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		return req, nil
+	})
+}
@@ -0,0 +1,53 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+)
+
+// MaxResponseSize bytes of a response body Do reads before giving up
+const MaxResponseSize = 1 << 20
+
+// NoContent response type of requests whose body is ignored
+type NoContent struct{}
+
+// Do sends a request to the endpoint and decodes the JSON response into T.
+// Responses with a status other than the expected ones, http.StatusOK if none are given, return an *Error.
+// The body is always closed, and bodies over MaxResponseSize are rejected.
+func Do[T any](ctx context.Context, c *Client, method, endpoint string, body any, expected ...int) (T, error) {
+	var result T
+	if len(expected) == 0 {
+		expected = []int{http.StatusOK}
+	}
+
+	resp, err := c.Request(ctx, method, endpoint, body)
+	if err != nil {
+		return result, err
+	}
+	defer resp.Body.Close()
+
+	if !slices.Contains(expected, resp.StatusCode) {
+		return result, c.responseError(resp)
+	}
+	if _, ok := any(result).(NoContent); ok || resp.StatusCode == http.StatusNoContent {
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, MaxResponseSize))
+		return result, nil
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, MaxResponseSize+1))
+	if err != nil {
+		return result, fmt.Errorf("%s %s: reading response: %w", method, endpoint, err)
+	}
+	if len(data) > MaxResponseSize {
+		return result, fmt.Errorf("%s %s: response larger than %d bytes", method, endpoint, MaxResponseSize)
+	}
+	err = json.Unmarshal(data, &result)
+	if err != nil {
+		return result, fmt.Errorf("%s %s: decoding response: %w", method, endpoint, err)
+	}
+	return result, nil
+}
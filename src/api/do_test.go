@@ -0,0 +1,138 @@
+package api_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NeuralNexusDev/neuralnexus-discord-bot/src/api"
+	"github.com/NeuralNexusDev/neuralnexus-discord-bot/src/api/apitest"
+)
+
+// testRetryPolicy retries like the default policy, without the waiting
+var testRetryPolicy = api.RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   time.Millisecond,
+	MaxDelay:    10 * time.Millisecond,
+	Budget:      time.Second,
+}
+
+// newTestClient returns a client for a mock API serving the default fixtures.
+// The handler, if given, wraps the mock server, e.g. to add response headers.
+func newTestClient(t *testing.T, wrap ...func(http.Handler) http.Handler) (*api.Client, *apitest.Server) {
+	t.Helper()
+	mock := apitest.NewServer(apitest.DefaultFixtures())
+	var handler http.Handler = mock
+	for _, w := range wrap {
+		handler = w(handler)
+	}
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	c := api.NewClient(srv.URL, "")
+	c.Retry = testRetryPolicy
+	return c, mock
+}
+
+func TestDoDecodesResponse(t *testing.T) {
+	c, _ := newTestClient(t)
+	name, err := api.Do[api.BeeName](context.Background(), c, http.MethodGet, "/bee-name-generator/name", nil)
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	if name.Name == "" {
+		t.Error("Do() decoded an empty bee name")
+	}
+}
+
+func TestDoNoContent(t *testing.T) {
+	c, mock := newTestClient(t)
+	_, err := api.Do[api.NoContent](context.Background(), c, http.MethodPost, "/bee-name-generator/name/Beethoven", nil)
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	if names := mock.BeeNames(); names[len(names)-1] != "Beethoven" {
+		t.Errorf("bee names = %v, want Beethoven added", names)
+	}
+}
+
+func TestDoUnexpectedStatus(t *testing.T) {
+	c, _ := newTestClient(t)
+	_, err := api.Do[api.BeeName](context.Background(), c, http.MethodGet, "/bee-name-generator/name", nil, http.StatusCreated)
+	var apiErr *api.Error
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusOK {
+		t.Errorf("Do() error = %v, want an *api.Error with status 200", err)
+	}
+}
+
+func TestDoMalformedResponse(t *testing.T) {
+	c, mock := newTestClient(t)
+	mock.SetFaults(apitest.Faults{MalformedRate: 1})
+	_, err := api.Do[api.BeeName](context.Background(), c, http.MethodGet, "/bee-name-generator/name", nil)
+	if err == nil || !strings.Contains(err.Error(), "decoding response") {
+		t.Errorf("Do() error = %v, want a decoding error", err)
+	}
+}
+
+func TestDoResponseTooLarge(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`"` + strings.Repeat("a", api.MaxResponseSize) + `"`))
+	}))
+	defer srv.Close()
+	c := api.NewClient(srv.URL, "")
+	_, err := api.Do[string](context.Background(), c, http.MethodGet, "/big", nil)
+	if err == nil || !strings.Contains(err.Error(), "larger than") {
+		t.Errorf("Do() error = %v, want a response size error", err)
+	}
+}
+
+func TestErrorDecoding(t *testing.T) {
+	tests := []struct {
+		status   int
+		sentinel error
+	}{
+		{http.StatusNotFound, api.ErrNotFound},
+		{http.StatusUnauthorized, api.ErrUnauthorized},
+		{http.StatusForbidden, api.ErrUnauthorized},
+		{http.StatusTooManyRequests, api.ErrRateLimited},
+		{http.StatusInternalServerError, api.ErrUnavailable},
+		{http.StatusServiceUnavailable, api.ErrUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			c, mock := newTestClient(t)
+			c.Retry = api.RetryPolicy{}
+			mock.FailNext(1, tt.status)
+
+			_, err := c.GetBeeName(context.Background())
+			if !errors.Is(err, tt.sentinel) {
+				t.Fatalf("GetBeeName() error = %v, want %v", err, tt.sentinel)
+			}
+			var apiErr *api.Error
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("GetBeeName() error = %T, want *api.Error", err)
+			}
+			if apiErr.StatusCode != tt.status || apiErr.Detail != "injected failure" || apiErr.RequestID == "" {
+				t.Errorf("error = %+v, want status %d, the problem detail and a request ID", apiErr, tt.status)
+			}
+			if apiErr.Method != http.MethodGet || apiErr.Endpoint != "/bee-name-generator/name" {
+				t.Errorf("error = %s %s, want GET /bee-name-generator/name", apiErr.Method, apiErr.Endpoint)
+			}
+		})
+	}
+}
+
+func TestInvalidInputIsNotSent(t *testing.T) {
+	c, mock := newTestClient(t)
+	_, err := c.GetMCServerStatus(context.Background(), "127.0.0.1", false)
+	if !errors.Is(err, api.ErrInvalidInput) {
+		t.Errorf("GetMCServerStatus() error = %v, want ErrInvalidInput", err)
+	}
+	if n := mock.Requests(); n != 0 {
+		t.Errorf("requests = %d, want 0", n)
+	}
+}
@@ -0,0 +1,165 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxErrorBody bytes of an error response read for its problem detail
+const maxErrorBody = 64 << 10
+
+// maxDetailLength characters kept of a problem detail that isn't a JSON string
+const maxDetailLength = 200
+
+var (
+	// ErrNotFound the requested resource doesn't exist
+	ErrNotFound = errors.New("not found")
+	// ErrUnauthorized the API key is missing, invalid or lacks the permission
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrRateLimited too many requests were sent
+	ErrRateLimited = errors.New("rate limited")
+	// ErrUnavailable the API couldn't be reached or failed to handle the request
+	ErrUnavailable = errors.New("unavailable")
+)
+
+// Error failed NeuralNexus API request, matching ErrNotFound, ErrUnauthorized, ErrRateLimited
+// or ErrUnavailable with errors.Is
+type Error struct {
+	// StatusCode HTTP status, 0 if no response was received
+	StatusCode int
+	// Detail problem detail from the response body, if any
+	Detail   string
+	Method   string
+	Endpoint string
+	// RequestID request ID the API assigned, for looking the request up in its logs
+	RequestID string
+	// Err transport error if no response was received
+	Err error
+}
+
+func (e *Error) Error() string {
+	var sb strings.Builder
+	sb.WriteString(e.Method + " " + e.Endpoint + ": ")
+	if e.StatusCode == 0 {
+		sb.WriteString(e.Err.Error())
+	} else {
+		sb.WriteString(fmt.Sprintf("%d %s", e.StatusCode, http.StatusText(e.StatusCode)))
+	}
+	if e.Detail != "" {
+		sb.WriteString(": " + e.Detail)
+	}
+	if e.RequestID != "" {
+		sb.WriteString(" (request ID " + e.RequestID + ")")
+	}
+	return sb.String()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is matches the sentinel errors by status code
+func (e *Error) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrUnavailable:
+		return e.StatusCode == 0 || e.StatusCode >= http.StatusInternalServerError
+	}
+	return false
+}
+
+// responseError returns the error for the unsuccessful response, decoding its problem detail
+func (c *Client) responseError(resp *http.Response) *Error {
+	e := &Error{
+		StatusCode: resp.StatusCode,
+		RequestID:  requestID(resp.Header),
+	}
+	if resp.Request != nil {
+		e.Method = resp.Request.Method
+		e.Endpoint = c.endpoint(resp.Request)
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	e.Detail = problemDetail(body)
+	return e
+}
+
+// transportError returns the error for a request that didn't get a response
+func (c *Client) transportError(req *http.Request, err error) *Error {
+	return &Error{
+		Method:   req.Method,
+		Endpoint: c.endpoint(req),
+		Err:      err,
+	}
+}
+
+// endpoint returns the request's path and query relative to the client's base URL
+func (c *Client) endpoint(req *http.Request) string {
+	uri := req.URL.RequestURI()
+	if i := strings.Index(c.BaseURL, "://"); i >= 0 {
+		if j := strings.Index(c.BaseURL[i+3:], "/"); j >= 0 {
+			uri = strings.TrimPrefix(uri, c.BaseURL[i+3+j:])
+		}
+	}
+	return uri
+}
+
+// requestID returns the request ID from the response headers
+func requestID(header http.Header) string {
+	for _, name := range []string{"X-Request-ID", "X-Correlation-ID", "CF-Ray"} {
+		if id := header.Get(name); id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
+// problemDetail extracts the detail from an error body: an RFC 9457 problem, a {"detail": ...} object
+// like FastAPI's, or plain text
+func problemDetail(body []byte) string {
+	body = bytes.TrimSpace(body)
+	if len(body) == 0 {
+		return ""
+	}
+	var problem struct {
+		Title   string          `json:"title"`
+		Detail  json.RawMessage `json:"detail"`
+		Message string          `json:"message"`
+		Error   string          `json:"error"`
+	}
+	if json.Unmarshal(body, &problem) != nil {
+		if !utf8.Valid(body) || bytes.HasPrefix(body, []byte("<")) {
+			return ""
+		}
+		return truncate(string(body))
+	}
+
+	var detail string
+	if len(problem.Detail) > 0 && json.Unmarshal(problem.Detail, &detail) != nil {
+		detail = truncate(string(problem.Detail))
+	}
+	for _, d := range []string{detail, problem.Message, problem.Error, problem.Title} {
+		if d != "" {
+			return d
+		}
+	}
+	return ""
+}
+
+// truncate shortens s to maxDetailLength characters
+func truncate(s string) string {
+	if utf8.RuneCountInString(s) <= maxDetailLength {
+		return s
+	}
+	return string([]rune(s)[:maxDetailLength-1]) + "…"
+}
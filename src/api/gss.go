@@ -1,13 +1,10 @@
 package api
 
 import (
-	"encoding/json"
-	"errors"
-	"log"
-	"net/http"
+	"context"
+	"net/url"
 	"strconv"
-
-	g "github.com/NeuralNexusDev/neuralnexus-discord-bot/src/globals"
+	"time"
 )
 
 // ServerStatus server status response
@@ -23,25 +20,41 @@ type ServerStatus struct {
 		ID   string `json:"id"`
 	} `json:"players"`
 	QueryType string `json:"query_type"`
+	// FetchedAt when the status was fetched, earlier than now if it came from the cache
+	FetchedAt time.Time `json:"-"`
 }
 
-// GetServerStatus fetches the server status from the NeuralNexus API
-func GetServerStatus(game, ip string, port int64) (*ServerStatus, error) {
-	resp, err := http.Get(g.NEURALNEXUS_API + "/game-server-status/" + game + "?host=" + ip + "&port=" + strconv.FormatInt(port, 10))
-	if err != nil {
+// GetServerStatus fetches the server status from the NeuralNexus API, or the status cache
+func (c *Client) GetServerStatus(ctx context.Context, game, ip string, port int64) (*ServerStatus, error) {
+	if err := validateName("game", game); err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		var body map[string]any
-		json.NewDecoder(resp.Body).Decode(&body)
-		log.Println("Error fetching server status:\n\t", body)
-		return nil, errors.New(body["detail"].(string))
+	if err := ValidateHost(ip); err != nil {
+		return nil, err
+	}
+	if err := ValidatePort(port); err != nil {
+		return nil, err
 	}
+	key := game + "|" + ip + "|" + strconv.FormatInt(port, 10)
+	status, fetchedAt, err := c.ServerStatusCache.Get(ctx, key, func(ctx context.Context) (*ServerStatus, error) {
+		return c.fetchServerStatus(ctx, game, ip, port)
+	})
+	if err != nil {
+		return nil, err
+	}
+	cached := *status
+	cached.FetchedAt = fetchedAt
+	return &cached, nil
+}
 
-	var status ServerStatus
-	err = json.NewDecoder(resp.Body).Decode(&status)
+// fetchServerStatus fetches the server status from the NeuralNexus API
+func (c *Client) fetchServerStatus(ctx context.Context, game, ip string, port int64) (*ServerStatus, error) {
+	endpoint := apiPath("game-server-status", game) + "?" + url.Values{
+		"host": {ip},
+		"port": {strconv.FormatInt(port, 10)},
+	}.Encode()
+	ctx = withRoute(ctx, "/game-server-status/{game}")
+	status, err := Do[ServerStatus](ctx, c, "GET", endpoint, nil)
 	if err != nil {
 		return nil, err
 	}
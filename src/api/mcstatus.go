@@ -1,12 +1,10 @@
 package api
 
 import (
-	"encoding/json"
-	"errors"
-	"log"
-	"net/http"
-
-	g "github.com/NeuralNexusDev/neuralnexus-discord-bot/src/globals"
+	"context"
+	"net/url"
+	"strconv"
+	"time"
 )
 
 // MCServerStatus server status response
@@ -25,27 +23,43 @@ type MCServerStatus struct {
 	Version    string `json:"version"`
 	Favicon    string `json:"favicon"`
 	ServerType string `json:"server_type"`
+	// FetchedAt when the status was fetched, earlier than now if it came from the cache
+	FetchedAt time.Time `json:"-"`
 }
 
-// GetMCServerStatus fetches the server status from the NeuralNexus API
-func GetMCServerStatus(host string) (*MCServerStatus, error) {
-	resp, err := http.Get(g.NEURALNEXUS_API + "/mcstatus/" + host)
+// GetMCServerStatus fetches the status of the Java or Bedrock Edition server at host, with an optional port,
+// from the NeuralNexus API or the status cache
+func (c *Client) GetMCServerStatus(ctx context.Context, host string, bedrock bool) (*MCServerStatus, error) {
+	if err := ValidateAddress(host); err != nil {
+		return nil, err
+	}
+	key := host + "|" + strconv.FormatBool(bedrock)
+	status, fetchedAt, err := c.MCStatusCache.Get(ctx, key, func(ctx context.Context) (*MCServerStatus, error) {
+		return c.fetchMCServerStatus(ctx, host, bedrock)
+	})
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
+	cached := *status
+	cached.FetchedAt = fetchedAt
+	return &cached, nil
+}
 
-	if resp.StatusCode != http.StatusOK {
-		var body map[string]interface{}
-		json.NewDecoder(resp.Body).Decode(&body)
-		log.Println("Error fetching server status:\n\t", body)
-		return nil, errors.New(body["detail"].(string))
+// fetchMCServerStatus fetches the server status from the NeuralNexus API
+func (c *Client) fetchMCServerStatus(ctx context.Context, host string, bedrock bool) (*MCServerStatus, error) {
+	endpoint := apiPath("mcstatus", host)
+	if bedrock {
+		endpoint += "?" + url.Values{"bedrock": {"true"}}.Encode()
 	}
-
-	var status MCServerStatus
-	err = json.NewDecoder(resp.Body).Decode(&status)
+	ctx = withRoute(ctx, "/mcstatus/{host}")
+	status, err := Do[MCServerStatus](ctx, c, "GET", endpoint, nil)
 	if err != nil {
 		return nil, err
 	}
 	return &status, nil
 }
+
+// MCServerIconURL returns the URL of the server's icon
+func (c *Client) MCServerIconURL(host string) string {
+	return c.BaseURL + apiPath("mcstatus", "icon", host)
+}
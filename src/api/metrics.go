@@ -0,0 +1,21 @@
+package api
+
+import "github.com/NeuralNexusDev/neuralnexus-discord-bot/src/metrics"
+
+// RequestLatency latency of requests to the NeuralNexus API
+var RequestLatency = metrics.NewTimer("api.request_latency")
+
+// RequestRetries retries of requests to the NeuralNexus API
+var RequestRetries = metrics.NewCounter("api.request_retries")
+
+// RetriesExhausted requests to the NeuralNexus API that kept failing after the retries their policy allowed
+var RetriesExhausted = metrics.NewCounter("api.retries_exhausted")
+
+// BreakerTrips times the NeuralNexus API circuit breaker tripped
+var BreakerTrips = metrics.NewCounter("api.breaker_trips")
+
+// MCStatusCacheStats hits and misses of the Minecraft server status cache
+var MCStatusCacheStats = metrics.NewCacheStats("mcstatus")
+
+// ServerStatusCacheStats hits and misses of the game server status cache
+var ServerStatusCacheStats = metrics.NewCacheStats("gstatus")
@@ -0,0 +1,207 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/NeuralNexusDev/neuralnexus-discord-bot/src/telemetry"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RetryPolicy how read-only requests are retried after transient failures
+type RetryPolicy struct {
+	// MaxAttempts attempts per call including the first, requests aren't retried if it's 1 or less
+	MaxAttempts int
+	// BaseDelay delay before the first retry, doubled for each further retry
+	BaseDelay time.Duration
+	// MaxDelay cap on the delay between attempts, retrying stops if Retry-After asks for longer
+	MaxDelay time.Duration
+	// Budget total time a call may spend on attempts and delays, no further attempts are started past it
+	Budget time.Duration
+}
+
+// DefaultRetryPolicy retry policy of clients created by NewClient
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   250 * time.Millisecond,
+	MaxDelay:    2 * time.Second,
+	Budget:      5 * time.Second,
+}
+
+// idempotent checks whether requests with the method can be safely sent more than once.
+// PUT and DELETE aren't: the API's suggestion and deletion endpoints fail if the first attempt went through.
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+// retryable checks whether the attempt failed in a way a later attempt might not
+func retryable(resp *http.Response, err error) bool {
+	if err != nil {
+		// An open breaker fails fast, and invalid input fails the same way every time
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded) &&
+			!errors.Is(err, ErrCircuitOpen) && !errors.Is(err, ErrInvalidInput)
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// backoff returns the delay before the given retry, starting at 1: exponential with equal jitter,
+// or what the server asked for with Retry-After. It returns false if the server asked to wait longer than MaxDelay.
+func (p RetryPolicy) backoff(retry int, resp *http.Response) (time.Duration, bool) {
+	if after, ok := retryAfter(resp); ok {
+		return after, after <= p.MaxDelay
+	}
+	d := p.BaseDelay << (retry - 1)
+	if d <= 0 || d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	if d <= 0 {
+		return 0, true
+	}
+	return d/2 + rand.N(d/2+1), true
+}
+
+// retryAfter parses the response's Retry-After header, in seconds or as an HTTP date
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+	header := resp.Header.Get("Retry-After")
+	if header == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(header); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(header); err == nil {
+		return max(time.Until(date), 0), true
+	}
+	return 0, false
+}
+
+// do sends the request built by newRequest unless the circuit breaker is open,
+// retrying idempotent requests as the client's policy allows.
+// The last attempt's response or error is returned once retrying stops.
+func (c *Client) do(ctx context.Context, method string, newRequest func() (*http.Request, error)) (*http.Response, error) {
+	policy := c.Retry
+	attempts := 1
+	if idempotent(method) {
+		attempts = max(policy.MaxAttempts, 1)
+	}
+	var budget time.Time
+	if policy.Budget > 0 {
+		budget = time.Now().Add(policy.Budget)
+	}
+
+	for attempt := 1; ; attempt++ {
+		req, err := newRequest()
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.send(req, attempt)
+		if attempts == 1 || !retryable(resp, err) {
+			return resp, err
+		}
+
+		delay, ok := policy.backoff(attempt, resp)
+		if !ok {
+			// The server won't take requests again before we'd give up
+			return resp, err
+		}
+		resume := time.Now().Add(delay)
+		deadline, ok := ctx.Deadline()
+		if attempt >= attempts || (ok && resume.After(deadline)) || (!budget.IsZero() && resume.After(budget)) {
+			RetriesExhausted.Inc()
+			return resp, err
+		}
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+
+		RequestRetries.Inc()
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// send sends one attempt of the request in a client span, propagating the trace context to the API
+func (c *Client) send(req *http.Request, attempt int) (*http.Response, error) {
+	name := req.Method
+	attrs := []attribute.KeyValue{
+		semconv.HTTPRequestMethodKey.String(req.Method),
+		semconv.ServerAddress(req.URL.Hostname()),
+	}
+	if route, ok := req.Context().Value(routeKey{}).(string); ok {
+		name += " " + route
+		attrs = append(attrs, semconv.URLTemplate(route))
+	}
+	ctx, span := telemetry.Tracer().Start(req.Context(), name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+	defer span.End()
+	if attempt > 1 {
+		span.SetAttributes(semconv.HTTPRequestResendCount(attempt - 1))
+	}
+	req = req.WithContext(ctx)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	if err := c.Breaker.Allow(); err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, c.transportError(req, err)
+	}
+	start := time.Now()
+	resp, err := c.HTTPClient.Do(req)
+	RequestLatency.Since(start)
+	if req.Context().Err() != nil {
+		// The caller gave up or ran out of time, that says nothing about the API
+		c.Breaker.Cancel()
+	} else {
+		c.Breaker.Record(breakerFailure(resp, err))
+	}
+
+	if err != nil {
+		// *url.Error holds the full URL, record only the cause
+		cause := err
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			cause = urlErr.Err
+		}
+		span.RecordError(cause)
+		span.SetStatus(codes.Error, cause.Error())
+		return nil, c.transportError(req, err)
+	}
+	span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+	if id := requestID(resp.Header); id != "" {
+		span.SetAttributes(attribute.String("neuralnexus.request_id", id))
+	}
+	if resp.StatusCode >= http.StatusInternalServerError {
+		span.SetStatus(codes.Error, resp.Status)
+	}
+	return resp, nil
+}
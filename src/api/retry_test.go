@@ -0,0 +1,161 @@
+package api_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/NeuralNexusDev/neuralnexus-discord-bot/src/api"
+)
+
+// withHeader sets a response header on every response
+func withHeader(key, value string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set(key, value)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func TestRetryRecoversFromTransientFailures(t *testing.T) {
+	for _, status := range []int{http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout} {
+		t.Run(http.StatusText(status), func(t *testing.T) {
+			c, mock := newTestClient(t)
+			mock.FailNext(2, status)
+			retries := api.RequestRetries.Value()
+
+			_, err := c.GetBeeName(context.Background())
+			if err != nil {
+				t.Fatalf("GetBeeName() error = %v", err)
+			}
+			if n := mock.Requests(); n != 3 {
+				t.Errorf("requests = %d, want 3", n)
+			}
+			if n := api.RequestRetries.Value() - retries; n != 2 {
+				t.Errorf("retries = %d, want 2", n)
+			}
+		})
+	}
+}
+
+func TestRetryGivesUpAfterMaxAttempts(t *testing.T) {
+	c, mock := newTestClient(t)
+	mock.FailNext(5, http.StatusServiceUnavailable)
+	exhausted := api.RetriesExhausted.Value()
+
+	_, err := c.GetBeeName(context.Background())
+	if !errors.Is(err, api.ErrUnavailable) {
+		t.Fatalf("GetBeeName() error = %v, want ErrUnavailable", err)
+	}
+	if n := mock.Requests(); n != testRetryPolicy.MaxAttempts {
+		t.Errorf("requests = %d, want %d", n, testRetryPolicy.MaxAttempts)
+	}
+	if n := api.RetriesExhausted.Value() - exhausted; n != 1 {
+		t.Errorf("retries exhausted = %d, want 1", n)
+	}
+}
+
+func TestRetryOnlyReads(t *testing.T) {
+	tests := []struct {
+		name string
+		call func(c *api.Client) error
+	}{
+		{"POST", func(c *api.Client) error { return c.UploadBeeName(context.Background(), "Beethoven") }},
+		{"PUT", func(c *api.Client) error { return c.AcceptBeeNameSuggestion(context.Background(), "Beelzebub") }},
+		{"DELETE", func(c *api.Client) error { return c.DeleteBeeName(context.Background(), "Sting") }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, mock := newTestClient(t)
+			mock.FailNext(1, http.StatusServiceUnavailable)
+
+			err := tt.call(c)
+			if !errors.Is(err, api.ErrUnavailable) {
+				t.Fatalf("error = %v, want ErrUnavailable", err)
+			}
+			if n := mock.Requests(); n != 1 {
+				t.Errorf("requests = %d, want 1", n)
+			}
+		})
+	}
+}
+
+func TestRetryNotOnClientErrors(t *testing.T) {
+	c, mock := newTestClient(t)
+	mock.FailNext(1, http.StatusNotFound)
+
+	_, err := c.GetBeeName(context.Background())
+	if !errors.Is(err, api.ErrNotFound) {
+		t.Fatalf("GetBeeName() error = %v, want ErrNotFound", err)
+	}
+	if n := mock.Requests(); n != 1 {
+		t.Errorf("requests = %d, want 1", n)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	c, mock := newTestClient(t, withHeader("Retry-After", "0"))
+	mock.FailNext(1, http.StatusTooManyRequests)
+
+	_, err := c.GetBeeName(context.Background())
+	if err != nil {
+		t.Fatalf("GetBeeName() error = %v", err)
+	}
+	if n := mock.Requests(); n != 2 {
+		t.Errorf("requests = %d, want 2", n)
+	}
+}
+
+func TestRetryAfterLongerThanMaxDelay(t *testing.T) {
+	c, mock := newTestClient(t, withHeader("Retry-After", "60"))
+	mock.FailNext(1, http.StatusTooManyRequests)
+
+	start := time.Now()
+	_, err := c.GetBeeName(context.Background())
+	if !errors.Is(err, api.ErrRateLimited) {
+		t.Fatalf("GetBeeName() error = %v, want ErrRateLimited", err)
+	}
+	if n := mock.Requests(); n != 1 {
+		t.Errorf("requests = %d, want 1", n)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("GetBeeName() took %v, want it to give up at once", elapsed)
+	}
+}
+
+func TestRetryStopsAtContextDeadline(t *testing.T) {
+	c, mock := newTestClient(t)
+	c.Retry = api.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Second, MaxDelay: time.Second}
+	mock.FailNext(3, http.StatusServiceUnavailable)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := c.GetBeeName(ctx)
+	if !errors.Is(err, api.ErrUnavailable) {
+		t.Fatalf("GetBeeName() error = %v, want the last attempt's ErrUnavailable", err)
+	}
+	if n := mock.Requests(); n != 1 {
+		t.Errorf("requests = %d, want 1", n)
+	}
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Errorf("GetBeeName() took %v, want it to give up before the deadline", elapsed)
+	}
+}
+
+func TestRetryBudget(t *testing.T) {
+	c, mock := newTestClient(t)
+	c.Retry = api.RetryPolicy{MaxAttempts: 10, BaseDelay: 20 * time.Millisecond, MaxDelay: 20 * time.Millisecond, Budget: 50 * time.Millisecond}
+	mock.FailNext(10, http.StatusServiceUnavailable)
+
+	_, err := c.GetBeeName(context.Background())
+	if !errors.Is(err, api.ErrUnavailable) {
+		t.Fatalf("GetBeeName() error = %v, want ErrUnavailable", err)
+	}
+	if n := mock.Requests(); n >= 10 {
+		t.Errorf("requests = %d, want the budget to stop retrying early", n)
+	}
+}
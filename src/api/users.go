@@ -1,41 +1,11 @@
 package api
 
 import (
-	"bytes"
-	"encoding/json"
-	"errors"
-	"net/http"
+	"context"
+	"slices"
 	"time"
-
-	g "github.com/NeuralNexusDev/neuralnexus-discord-bot/src/globals"
 )
 
-// APIRequest request helper method for the NeuralNexus API
-func APIRequest(method, endpoint string, body interface{}) (*http.Response, error) {
-	buff := new(bytes.Buffer)
-	if body != nil {
-		b, err := json.Marshal(body)
-		if err != nil {
-			return nil, err
-		}
-		buff = bytes.NewBuffer(b)
-	}
-
-	req, err := http.NewRequest(method, g.NEURALNEXUS_API+endpoint, buff)
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Set("Authorization", "Bearer "+g.NEURALNEXUS_API_KEY)
-	req.Header.Set("Content-Type", "application/json")
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	return resp, nil
-}
-
 // User struct
 type User struct {
 	UserID      string    `json:"user_id"`
@@ -43,121 +13,78 @@ type User struct {
 	Roles       []string  `json:"roles"`
 	Permissions []string  `json:"permissions"`
 	UpdatedAt   time.Time `json:"updated_at"`
+	client      *Client
 }
 
-// HasPermission checks if the user has the specified permission
-func (u *User) HasPermission(permission string) bool {
+// HasPermission checks if the user has the specified permission, fetching their permissions if they weren't included
+func (u *User) HasPermission(ctx context.Context, permission string) (bool, error) {
 	if u.Permissions == nil {
-		p, err := GetUserPermissions(u.UserID)
+		if u.client == nil {
+			return false, nil
+		}
+		p, err := u.client.GetUserPermissions(ctx, u.UserID)
 		if err != nil {
-			return false
+			return false, err
 		}
 		u.Permissions = p
 	}
-	for _, p := range u.Permissions {
-		if p == permission {
-			return true
-		}
-	}
-	return false
+	return slices.Contains(u.Permissions, permission), nil
 }
 
 // GetUser fetches the user from the NeuralNexus API
-func GetUser(userID string) (*User, error) {
-	resp, err := APIRequest("GET", "/users/"+userID, nil)
-	if err != nil {
+func (c *Client) GetUser(ctx context.Context, userID string) (*User, error) {
+	if err := validateName("user ID", userID); err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, errors.New("error fetching user")
-	}
-
-	var user User
-	err = json.NewDecoder(resp.Body).Decode(&user)
-	if err != nil {
-		return nil, err
-	}
-	return &user, nil
+	return c.doUser(withRoute(ctx, "/users/{id}"), "GET", apiPath("users", userID), nil)
 }
 
 // GetUserFromPlatform fetches the user from the NeuralNexus API
-func GetUserFromPlatform(platform, platformID string) (*User, error) {
-	resp, err := APIRequest("GET", "/users/"+platform+"/"+platformID, nil)
-	if err != nil {
+func (c *Client) GetUserFromPlatform(ctx context.Context, platform, platformID string) (*User, error) {
+	if err := validatePlatform(platform, platformID); err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, errors.New("error fetching user")
-	}
-
-	var user User
-	err = json.NewDecoder(resp.Body).Decode(&user)
-	if err != nil {
-		return nil, err
-	}
-	return &user, nil
+	return c.doUser(withRoute(ctx, "/users/{platform}/{platformID}"), "GET", apiPath("users", platform, platformID), nil)
 }
 
 // GetUserPermissions fetches the user permissions from the NeuralNexus API
-func GetUserPermissions(userID string) ([]string, error) {
-	resp, err := APIRequest("GET", "/users/"+userID+"/permissions", nil)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, errors.New("error fetching user permissions")
-	}
-
-	var permissions []string
-	err = json.NewDecoder(resp.Body).Decode(&permissions)
-	if err != nil {
+func (c *Client) GetUserPermissions(ctx context.Context, userID string) ([]string, error) {
+	if err := validateName("user ID", userID); err != nil {
 		return nil, err
 	}
-	return permissions, nil
+	return Do[[]string](withRoute(ctx, "/users/{id}/permissions"), c, "GET", apiPath("users", userID, "permissions"), nil)
 }
 
 // UpdateUser updates the user in the NeuralNexus API
-func UpdateUser(userID string, user *User) (*User, error) {
-	resp, err := APIRequest("PUT", "/users/"+userID, user)
-	if err != nil {
+func (c *Client) UpdateUser(ctx context.Context, userID string, user *User) (*User, error) {
+	if err := validateName("user ID", userID); err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, errors.New("error updating user")
-	}
+	return c.doUser(withRoute(ctx, "/users/{id}"), "PUT", apiPath("users", userID), user)
+}
 
-	var updatedUser User
-	err = json.NewDecoder(resp.Body).Decode(&updatedUser)
-	if err != nil {
+// UpdateUserPlatform updates the user in the NeuralNexus API
+func (c *Client) UpdateUserPlatform(ctx context.Context, platform, platformID string, data interface{}) (*User, error) {
+	if err := validatePlatform(platform, platformID); err != nil {
 		return nil, err
 	}
-	return &updatedUser, nil
+	return c.doUser(withRoute(ctx, "/users/{platform}/{platformID}"), "PUT", apiPath("users", platform, platformID), data)
 }
 
-// UpdateUserPlatform updates the user in the NeuralNexus API
-func UpdateUserPlatform(platform, platformID string, data interface{}) (*User, error) {
-	resp, err := APIRequest("PUT", "/users/"+platform+"/"+platformID, data)
+// doUser sends a request responding with a user, which fetches its permissions through the client
+func (c *Client) doUser(ctx context.Context, method, endpoint string, body any) (*User, error) {
+	user, err := Do[User](ctx, c, method, endpoint, body)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, errors.New("error updating user")
-	}
+	user.client = c
+	return &user, nil
+}
 
-	var updatedUser User
-	err = json.NewDecoder(resp.Body).Decode(&updatedUser)
-	if err != nil {
-		return nil, err
+// validatePlatform checks a platform and the user's ID on it
+func validatePlatform(platform, platformID string) error {
+	if err := validateName("platform", platform); err != nil {
+		return err
 	}
-	return &updatedUser, nil
+	return validateName("platform user ID", platformID)
 }
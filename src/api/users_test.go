@@ -0,0 +1,39 @@
+package api_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/NeuralNexusDev/neuralnexus-discord-bot/src/api"
+	"github.com/NeuralNexusDev/neuralnexus-discord-bot/src/api/apitest"
+)
+
+func TestHasPermission(t *testing.T) {
+	c, _ := newTestClient(t)
+	user, err := c.UpdateUserPlatform(context.Background(), "discord", "1001", map[string]string{"username": "bob"})
+	if err != nil {
+		t.Fatalf("UpdateUserPlatform() error = %v", err)
+	}
+
+	allowed, err := user.HasPermission(context.Background(), apitest.BeeNamePermission)
+	if err != nil || allowed {
+		t.Errorf("HasPermission() = %v, %v, want false, nil", allowed, err)
+	}
+}
+
+func TestHasPermissionReturnsLookupErrors(t *testing.T) {
+	c, mock := newTestClient(t)
+	c.Retry = api.RetryPolicy{}
+	user, err := c.UpdateUserPlatform(context.Background(), "discord", "1001", map[string]string{"username": "bob"})
+	if err != nil {
+		t.Fatalf("UpdateUserPlatform() error = %v", err)
+	}
+	mock.FailNext(1, http.StatusServiceUnavailable)
+
+	allowed, err := user.HasPermission(context.Background(), apitest.BeeNamePermission)
+	if !errors.Is(err, api.ErrUnavailable) || allowed {
+		t.Errorf("HasPermission() = %v, %v, want false, ErrUnavailable", allowed, err)
+	}
+}
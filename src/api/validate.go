@@ -0,0 +1,91 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidInput a request parameter failed validation, no request was sent
+var ErrInvalidInput = errors.New("invalid input")
+
+// maxHostnameLength longest hostname DNS allows, without the trailing dot
+const maxHostnameLength = 253
+
+// hostnameLabelPattern valid hostname label
+var hostnameLabelPattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+// ValidateHost checks that host is a public hostname or IP address, without a port
+func ValidateHost(host string) error {
+	if host == "" {
+		return fmt.Errorf("%w: no host given", ErrInvalidInput)
+	}
+	if ip, err := netip.ParseAddr(host); err == nil {
+		return validateIP(host, ip)
+	}
+
+	name := strings.TrimSuffix(strings.ToLower(host), ".")
+	labels := strings.Split(name, ".")
+	if len(name) > maxHostnameLength || len(labels) < 2 {
+		return fmt.Errorf("%w: %q isn't a valid hostname or IP address", ErrInvalidInput, host)
+	}
+	for _, label := range labels {
+		if !hostnameLabelPattern.MatchString(label) {
+			return fmt.Errorf("%w: %q isn't a valid hostname or IP address", ErrInvalidInput, host)
+		}
+	}
+	if tld := labels[len(labels)-1]; tld == "localhost" || tld == "local" || tld == "internal" {
+		return fmt.Errorf("%w: %q isn't a public hostname", ErrInvalidInput, host)
+	}
+	return nil
+}
+
+// ValidatePort checks that port is a valid TCP/UDP port
+func ValidatePort(port int64) error {
+	if port < 1 || port > 65535 {
+		return fmt.Errorf("%w: port %d must be between 1 and 65535", ErrInvalidInput, port)
+	}
+	return nil
+}
+
+// ValidateAddress checks that addr is a public hostname or IP address with an optional port,
+// like "mc.example.com", "mc.example.com:25566" or "[2001:db8::1]:25565"
+func ValidateAddress(addr string) error {
+	if _, err := netip.ParseAddr(addr); err == nil {
+		return ValidateHost(addr)
+	}
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return ValidateHost(addr)
+	}
+	p, err := strconv.ParseInt(port, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: port %q isn't a number", ErrInvalidInput, port)
+	}
+	if err := ValidatePort(p); err != nil {
+		return err
+	}
+	return ValidateHost(host)
+}
+
+// validateIP rejects addresses that don't reach a public server
+func validateIP(host string, ip netip.Addr) error {
+	ip = ip.Unmap()
+	if ip.Zone() != "" || ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsMulticast() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() {
+		return fmt.Errorf("%w: %q isn't a public IP address", ErrInvalidInput, host)
+	}
+	return nil
+}
+
+// validateName checks a path parameter like a bee name or user ID
+func validateName(kind, name string) error {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("%w: no %s given", ErrInvalidInput, kind)
+	}
+	return nil
+}
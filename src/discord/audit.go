@@ -0,0 +1,204 @@
+package discord
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NeuralNexusDev/neuralnexus-discord-bot/src/i18n"
+	"github.com/NeuralNexusDev/neuralnexus-discord-bot/src/storage"
+	"github.com/bwmarrin/discordgo"
+)
+
+//goland:noinspection GoSnakeCaseUsage
+var (
+	AUDIT_CHANNEL_ID = os.Getenv("AUDIT_CHANNEL_ID")
+)
+
+const (
+	// auditRetention how long audit entries are kept before the prune job deletes them
+	auditRetention = 90 * 24 * time.Hour
+	// maxAuditResults entries Search returns when the filter has no limit
+	maxAuditResults = 100
+)
+
+// AuditEntry record of a privileged action
+type AuditEntry struct {
+	ID        string    `json:"id"`
+	GuildID   string    `json:"guild_id,omitempty"`
+	ActorID   string    `json:"actor_id"`
+	ActorName string    `json:"actor_name"`
+	Action    string    `json:"action"`
+	Target    string    `json:"target,omitempty"`
+	Success   bool      `json:"success"`
+	Error     string    `json:"error,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// AuditFilter audit log search filter, empty fields match everything except GuildID
+type AuditFilter struct {
+	// GuildID guild whose entries are searched, empty for entries recorded in DMs
+	GuildID string
+	ActorID string
+	// Action action prefix, e.g. "beename" matches every bee name action
+	Action string
+	Limit  int
+}
+
+// AuditLog records privileged actions to storage and mirrors them to audit channels
+type AuditLog struct {
+	repo      *storage.Repository[AuditEntry]
+	settings  *GuildSettingsRepository
+	s         *discordgo.Session
+	channelID string
+}
+
+// NewAuditLog returns an audit log backed by the store, mirroring entries to channelID and each guild's audit channel
+func NewAuditLog(store storage.Store, settings *GuildSettingsRepository, s *discordgo.Session, channelID string) *AuditLog {
+	return &AuditLog{
+		repo:      storage.NewRepository[AuditEntry](store, AuditLogBucket),
+		settings:  settings,
+		s:         s,
+		channelID: channelID,
+	}
+}
+
+// auditPrefix key prefix of the guild's entries, or of entries recorded in DMs if guildID is empty
+func auditPrefix(guildID string) string {
+	if guildID == "" {
+		guildID = "dm"
+	}
+	return guildID + "/"
+}
+
+// auditKey orders entries by guild, then time; the sequence number keeps keys recorded at the same time unique
+func auditKey(guildID string, t time.Time, seq uint64) string {
+	return auditPrefix(guildID) + fmt.Sprintf("%020d-%020d", t.UnixNano(), seq)
+}
+
+// Record records the outcome of a privileged action taken through the interaction.
+// Entries are mirrored to the audit channels in the background, call it after responding to the interaction.
+func (a *AuditLog) Record(i *discordgo.InteractionCreate, action, target string, err error) {
+	actor := InteractionUser(i)
+	now := time.Now().UTC()
+	entry := &AuditEntry{
+		GuildID:   i.GuildID,
+		ActorID:   actor.ID,
+		ActorName: actor.Username,
+		Action:    action,
+		Target:    target,
+		Success:   err == nil,
+		Timestamp: now,
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+
+	err = a.repo.Insert(func(seq uint64) string {
+		entry.ID = auditKey(i.GuildID, now, seq)
+		return entry.ID
+	}, entry)
+	if err != nil {
+		log.Printf("Cannot record audit entry %s %s: %v", action, target, err)
+	}
+
+	guildChannelID := ""
+	if i.GuildID != "" {
+		guildChannelID = a.settings.Get(i.GuildID).AuditChannelID
+	}
+	locale := GuildLocale(i)
+	go func() {
+		a.mirror(a.channelID, i18n.DefaultLocale, entry)
+		if guildChannelID != a.channelID {
+			a.mirror(guildChannelID, locale, entry)
+		}
+	}()
+}
+
+// mirror posts the entry to the audit channel, if one is set
+func (a *AuditLog) mirror(channelID string, locale discordgo.Locale, entry *AuditEntry) {
+	if channelID == "" {
+		return
+	}
+	_, err := a.s.ChannelMessageSendEmbed(channelID, AuditEmbed(locale, entry))
+	if err != nil {
+		log.Printf("Cannot post audit entry to channel %s: %v", channelID, err)
+	}
+}
+
+// Search returns the most recent entries matching the filter, newest first, at most maxAuditResults if it has no limit.
+// An empty guild ID searches the entries recorded in DMs.
+func (a *AuditLog) Search(filter AuditFilter) ([]*AuditEntry, error) {
+	entries, err := a.repo.List(auditPrefix(filter.GuildID))
+	if err != nil {
+		return nil, err
+	}
+	entries = slices.DeleteFunc(entries, func(e *AuditEntry) bool {
+		return (filter.ActorID != "" && e.ActorID != filter.ActorID) || !strings.HasPrefix(e.Action, filter.Action)
+	})
+	slices.SortFunc(entries, func(x, y *AuditEntry) int {
+		return y.Timestamp.Compare(x.Timestamp)
+	})
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = maxAuditResults
+	}
+	if len(entries) > limit {
+		entries = entries[:limit]
+	}
+	return entries, nil
+}
+
+// Prune deletes entries older than auditRetention
+func (a *AuditLog) Prune(ctx context.Context) error {
+	cutoff := time.Now().Add(-auditRetention)
+	deleted, err := a.repo.DeleteFunc("", func(e *AuditEntry) bool {
+		return e.Timestamp.Before(cutoff)
+	})
+	if err != nil {
+		return err
+	}
+	if deleted > 0 {
+		log.Printf("Pruned %d audit entries older than %s", deleted, cutoff.Format(time.DateOnly))
+	}
+	return nil
+}
+
+// AuditEmbed returns an embed describing the audit entry
+func AuditEmbed(locale discordgo.Locale, e *AuditEntry) *discordgo.MessageEmbed {
+	color := EMBED_GREEN
+	result := i18n.T(locale, "audit.entry.success")
+	if !e.Success {
+		color = EMBED_RED
+		result = i18n.T(locale, "audit.entry.failed", e.Error)
+	}
+	embed := SimpleEmbed(i18n.T(locale, "audit.entry.title", e.Action), "", color)
+	embed.Timestamp = e.Timestamp.Format(time.RFC3339)
+	embed.Fields = []*discordgo.MessageEmbedField{
+		{Name: i18n.T(locale, "audit.entry.actor"), Value: "<@" + e.ActorID + "> (" + e.ActorName + ")", Inline: true},
+		{Name: i18n.T(locale, "audit.entry.target"), Value: orDash(e.Target), Inline: true},
+		{Name: i18n.T(locale, "audit.entry.result"), Value: result},
+	}
+	return embed
+}
+
+// AuditLine returns a one line summary of the audit entry
+func AuditLine(e *AuditEntry) string {
+	status := "✅"
+	if !e.Success {
+		status = "❌"
+	}
+	return status + " <t:" + strconv.FormatInt(e.Timestamp.Unix(), 10) + ":R> <@" + e.ActorID + "> `" + e.Action + "` " + orDash(e.Target)
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
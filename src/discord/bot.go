@@ -1,10 +1,22 @@
 package discord
 
 import (
+	"context"
+	"encoding/json"
 	"log"
+	"net/http"
 	"os"
 	"os/signal"
+	"slices"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/NeuralNexusDev/neuralnexus-discord-bot/src/api"
+	g "github.com/NeuralNexusDev/neuralnexus-discord-bot/src/globals"
+	"github.com/NeuralNexusDev/neuralnexus-discord-bot/src/i18n"
+	"github.com/NeuralNexusDev/neuralnexus-discord-bot/src/scheduler"
+	"github.com/NeuralNexusDev/neuralnexus-discord-bot/src/storage"
 	"github.com/bwmarrin/discordgo"
 )
 
@@ -13,102 +25,408 @@ var (
 	GUILD_ID        = os.Getenv("GUILD_ID")
 	BOT_TOKEN       = os.Getenv("BOT_TOKEN")
 	REMOVE_COMMANDS = os.Getenv("REMOVE_COMMANDS") == "true"
+	BOT_OWNER_IDS   = os.Getenv("BOT_OWNER_IDS")
+	HEALTH_ADDR     = os.Getenv("HEALTH_ADDR")
 )
 
 type InteractionHandler func(s *discordgo.Session, i *discordgo.InteractionCreate)
 
 type Bot struct {
-	GuildID           string
-	BotToken          string
-	RemoveCommands    bool
-	s                 *discordgo.Session
-	commands          []*discordgo.ApplicationCommand
-	commandHandlers   map[string]InteractionHandler
-	componentHandlers map[string]InteractionHandler
+	GuildID              string
+	BotToken             string
+	RemoveCommands       bool
+	StartedAt            time.Time
+	Store                storage.Store
+	Settings             *GuildSettingsRepository
+	Scheduler            *scheduler.Scheduler
+	Errors               *ErrorReporter
+	Audit                *AuditLog
+	API                  *api.Client
+	ctx                  context.Context
+	cancel               context.CancelFunc
+	s                    *discordgo.Session
+	commands             []*discordgo.ApplicationCommand
+	createdCommands      []*discordgo.ApplicationCommand
+	owners               []string
+	ownersMu             sync.Mutex
+	commandHandlers      map[string]InteractionHandler
+	componentHandlers    map[string]InteractionHandler
+	autocompleteHandlers map[string]InteractionHandler
+	duplicateComponents  []string
+	collectors           []*Collector
+	collectorsMu         sync.Mutex
+	interactions         sync.Map // interaction ID -> context.Context of its current span
+	coreCommands         map[string]bool
+	ownerCommands        map[string]bool
+	shutdownHooks        []func()
+	shutdownOnce         sync.Once
+	examples             map[string][]string
+	intents              discordgo.Intent
 }
 
 func NewBot() *Bot {
 	bot := &Bot{
-		GuildID:           GUILD_ID,
-		BotToken:          BOT_TOKEN,
-		RemoveCommands:    REMOVE_COMMANDS,
-		commands:          []*discordgo.ApplicationCommand{},
-		commandHandlers:   map[string]InteractionHandler{},
-		componentHandlers: map[string]InteractionHandler{},
+		GuildID:              GUILD_ID,
+		BotToken:             BOT_TOKEN,
+		RemoveCommands:       REMOVE_COMMANDS,
+		commands:             []*discordgo.ApplicationCommand{},
+		commandHandlers:      map[string]InteractionHandler{},
+		componentHandlers:    map[string]InteractionHandler{},
+		autocompleteHandlers: map[string]InteractionHandler{},
+		coreCommands:         map[string]bool{},
+		ownerCommands:        map[string]bool{},
+		examples:             map[string][]string{},
+		intents:              baseIntents,
 	}
 	s, err := discordgo.New("Bot " + BOT_TOKEN)
 	if err != nil {
 		log.Fatalf("Invalid bot parameters: %v", err)
 	}
 	bot.s = s
+
+	store, err := storage.OpenBolt(DATABASE_PATH, migrations)
+	if err != nil {
+		log.Fatalf("Cannot open the database: %v", err)
+	}
+	bot.Store = store
+	bot.Settings = NewGuildSettingsRepository(store)
+	bot.Scheduler = scheduler.New(NewJobRunRepository(store))
+	bot.Errors, err = NewErrorReporter(s, ERROR_CHANNEL_ID, ERROR_WEBHOOK_URL)
+	if err != nil {
+		log.Fatalf("Invalid error reporting parameters: %v", err)
+	}
+	bot.Audit = NewAuditLog(store, bot.Settings, s, AUDIT_CHANNEL_ID)
+	bot.AddJob(scheduler.Job{
+		Name:     "audit-prune",
+		Schedule: scheduler.MustCron("@daily"),
+		Jitter:   time.Hour,
+		Run:      bot.Audit.Prune,
+	})
+	bot.API = api.NewClient(g.NEURALNEXUS_API, g.NEURALNEXUS_API_KEY)
+	bot.Scheduler.OnError = func(job string, err error) {
+		bot.Errors.Report(ErrorReport{Source: "job " + job, Err: err})
+	}
+	bot.ctx, bot.cancel = context.WithCancel(context.Background())
+	bot.AddComponentHandler(confirmComponentID, bot.expireConfirmation)
 	return bot
 }
 
+// AddCommandHandler adds the command, localized from the catalogs, along with its handler
 func (b *Bot) AddCommandHandler(cmd *discordgo.ApplicationCommand, h InteractionHandler) {
 	log.Printf("Adding command handler for %q", cmd.Name)
 
+	i18n.LocalizeCommand(cmd)
 	b.commands = append(b.commands, cmd)
 	b.commandHandlers[cmd.Name] = h
 }
 
+// AddCoreCommandHandler adds a command handler that can't be disabled per guild
+func (b *Bot) AddCoreCommandHandler(cmd *discordgo.ApplicationCommand, h InteractionHandler) {
+	b.AddCommandHandler(cmd, h)
+	b.coreCommands[cmd.Name] = true
+}
+
+// AddOwnerCommandHandler adds a core command handler for the bot's owners, the handler still checks IsOwner
+func (b *Bot) AddOwnerCommandHandler(cmd *discordgo.ApplicationCommand, h InteractionHandler) {
+	b.AddCoreCommandHandler(cmd, h)
+	b.ownerCommands[cmd.Name] = true
+}
+
+// CanUseCommand checks whether the interaction user can use the command, going by its default member permissions,
+// so commands they can't use can be left out of listings
+func (b *Bot) CanUseCommand(i *discordgo.InteractionCreate, cmd *discordgo.ApplicationCommand) bool {
+	if b.ownerCommands[cmd.Name] {
+		return b.IsOwner(InteractionUser(i).ID)
+	}
+	if cmd.DefaultMemberPermissions == nil || *cmd.DefaultMemberPermissions == 0 {
+		return true
+	}
+	if i.Member == nil {
+		return false
+	}
+	required := *cmd.DefaultMemberPermissions
+	return i.Member.Permissions&discordgo.PermissionAdministrator != 0 || i.Member.Permissions&required == required
+}
+
+// Modules returns the names of the modules that can be enabled or disabled per guild
+func (b *Bot) Modules() []string {
+	var modules []string
+	for _, cmd := range b.commands {
+		if !b.coreCommands[cmd.Name] {
+			modules = append(modules, cmd.Name)
+		}
+	}
+	slices.Sort(modules)
+	return modules
+}
+
+// componentModule returns the module owning a component, matched by the "<module>_" custom ID prefix
+func (b *Bot) componentModule(customID string) string {
+	for _, module := range b.Modules() {
+		if strings.HasPrefix(customID, module+"_") {
+			return module
+		}
+	}
+	return ""
+}
+
+// moduleEnabled checks the guild's settings for the module, responding to the interaction if it's disabled
+func (b *Bot) moduleEnabled(s *discordgo.Session, i *discordgo.InteractionCreate, module string) bool {
+	if i.GuildID == "" || module == "" || b.Settings.Get(i.GuildID).ModuleEnabled(module) {
+		return true
+	}
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Flags:  discordgo.MessageFlagsEphemeral,
+			Embeds: []*discordgo.MessageEmbed{SimpleEmbed(i18n.T(i.Locale, "module.disabled.title"), i18n.T(i.Locale, "module.disabled.description", module), EMBED_YELLOW)},
+		},
+	})
+	if err != nil {
+		log.Printf("Error responding to disabled module interaction: %s", err)
+	}
+	return false
+}
+
+// logCommand posts the command usage to the guild's log channel, if one is set
+func (b *Bot) logCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if i.GuildID == "" || i.Member == nil {
+		return
+	}
+	channelID := b.Settings.Get(i.GuildID).LogChannelID
+	if channelID == "" {
+		return
+	}
+	locale := GuildLocale(i)
+	embed := SimpleEmbed(i18n.T(locale, "log.command.title"), i18n.T(locale, "log.command.description", i.Member.User.ID, i.ApplicationCommandData().Name, i.ChannelID), EMBED_YELLOW)
+	_, err := s.ChannelMessageSendEmbed(channelID, embed)
+	if err != nil {
+		log.Printf("Cannot post to log channel %s: %v", channelID, err)
+	}
+}
+
 func (b *Bot) AddComponentHandler(id string, h InteractionHandler) {
 	log.Printf("Adding component handler for %q", id)
 
+	if _, ok := b.componentHandlers[id]; ok {
+		b.duplicateComponents = append(b.duplicateComponents, id)
+	}
 	b.componentHandlers[id] = h
 }
 
+// componentHandler returns the handler for the custom ID, components carrying state in
+// their custom ID as "<id>:<state>" are handled by the handler registered for "<id>"
+func (b *Bot) componentHandler(customID string) (InteractionHandler, bool) {
+	if h, ok := b.componentHandlers[customID]; ok {
+		return h, true
+	}
+	id, _, ok := strings.Cut(customID, ":")
+	if !ok {
+		return nil, false
+	}
+	h, ok := b.componentHandlers[id]
+	return h, ok
+}
+
+// Commands returns the registered commands
+func (b *Bot) Commands() []*discordgo.ApplicationCommand {
+	return slices.Clone(b.commands)
+}
+
+// AddCommandExamples adds usage examples shown in the command's help page
+func (b *Bot) AddCommandExamples(command string, examples ...string) {
+	b.examples[command] = append(b.examples[command], examples...)
+}
+
+// CommandExamples returns the command's usage examples
+func (b *Bot) CommandExamples(command string) []string {
+	return b.examples[command]
+}
+
 func (b *Bot) AddComponentHandlers(h map[string]InteractionHandler) {
 	for id, handler := range h {
 		b.AddComponentHandler(id, handler)
 	}
 }
 
+// RegisterCommands validates the commands and overwrites the application's registered commands with them
+func (b *Bot) RegisterCommands() error {
+	err := b.ValidateCommands()
+	if err != nil {
+		return err
+	}
+	payload, err := commandsPayload(b.commands)
+	if err != nil {
+		return err
+	}
+	endpoint := discordgo.EndpointApplicationGlobalCommands(b.s.State.User.ID)
+	if GUILD_ID != "" {
+		endpoint = discordgo.EndpointApplicationGuildCommands(b.s.State.User.ID, GUILD_ID)
+	}
+	// Sent like ApplicationCommandBulkOverwrite, with the payload keeping max values of 0
+	body, err := b.s.RequestWithBucketID(http.MethodPut, endpoint, payload, endpoint)
+	if err != nil {
+		return err
+	}
+	var createdCommands []*discordgo.ApplicationCommand
+	err = json.Unmarshal(body, &createdCommands)
+	if err != nil {
+		return err
+	}
+	b.createdCommands = createdCommands
+	return nil
+}
+
+// IsOwner checks if the user is one of BOT_OWNER_IDS, or the application's owner or team members if unset
+func (b *Bot) IsOwner(userID string) bool {
+	b.ownersMu.Lock()
+	defer b.ownersMu.Unlock()
+
+	if b.owners == nil && strings.TrimSpace(BOT_OWNER_IDS) != "" {
+		b.owners = []string{}
+		for _, id := range strings.Split(BOT_OWNER_IDS, ",") {
+			if id = strings.TrimSpace(id); id != "" {
+				b.owners = append(b.owners, id)
+			}
+		}
+	} else if b.owners == nil {
+		app, err := b.s.Application("@me")
+		if err != nil {
+			log.Printf("Cannot fetch the application's owners: %v", err)
+			return false
+		}
+		if app.Owner != nil {
+			b.owners = append(b.owners, app.Owner.ID)
+		}
+		if app.Team != nil {
+			for _, m := range app.Team.Members {
+				b.owners = append(b.owners, m.User.ID)
+			}
+		}
+	}
+	return slices.Contains(b.owners, userID)
+}
+
+// OnShutdown adds a function run once the bot has stopped, or before it exits on a fatal error
+func (b *Bot) OnShutdown(fn func()) {
+	b.shutdownHooks = append(b.shutdownHooks, fn)
+}
+
+// shutdown runs the shutdown hooks, once
+func (b *Bot) shutdown() {
+	b.shutdownOnce.Do(func() {
+		for _, fn := range slices.Backward(b.shutdownHooks) {
+			fn()
+		}
+	})
+}
+
+// fatalf runs the shutdown hooks, then logs the error and exits like log.Fatalf
+func (b *Bot) fatalf(format string, v ...any) {
+	b.shutdown()
+	log.Fatalf(format, v...)
+}
+
 func (b *Bot) Start() {
+	defer b.shutdown()
+
+	err := b.ValidateCommands()
+	if err != nil {
+		b.fatalf("Invalid commands:\n%v", err)
+	}
+
+	b.s.Identify.Intents = b.intents
 	b.s.AddHandler(func(s *discordgo.Session, r *discordgo.Ready) { log.Println("Bot is up!") })
 	b.s.AddHandler(func(s *discordgo.Session, i *discordgo.InteractionCreate) {
 		log.Printf("Interaction received: %v", i.Type)
+		defer b.startInteractionSpan(i)()
+		defer b.recoverInteraction(i)
 
 		switch i.Type {
 		case discordgo.InteractionApplicationCommand:
-			log.Printf("Command: %v", i.ApplicationCommandData().Name)
+			name := i.ApplicationCommandData().Name
+			log.Printf("Command: %v", name)
 
-			if h, ok := b.commandHandlers[i.ApplicationCommandData().Name]; ok {
-				h(s, i)
+			if h, ok := b.commandHandlers[name]; ok {
+				if !b.coreCommands[name] && !b.moduleEnabled(s, i, name) {
+					return
+				}
+				b.runHandler(h, s, i)
+				b.logCommand(s, i)
+			}
+		case discordgo.InteractionApplicationCommandAutocomplete:
+			if h, ok := b.autocompleteHandlers[i.ApplicationCommandData().Name]; ok {
+				b.runHandler(h, s, i)
 			}
 		case discordgo.InteractionMessageComponent:
-			log.Printf("ComponentID: %v", i.MessageComponentData().CustomID)
+			customID := i.MessageComponentData().CustomID
+			log.Printf("ComponentID: %v", customID)
+
+			if b.collect(s, i) {
+				return
+			}
+			if h, ok := b.componentHandler(customID); ok {
+				if !b.moduleEnabled(s, i, b.componentModule(customID)) {
+					return
+				}
+				b.runHandler(h, s, i)
+			}
+		case discordgo.InteractionModalSubmit:
+			customID := i.ModalSubmitData().CustomID
+			log.Printf("ModalID: %v", customID)
 
-			if h, ok := b.componentHandlers[i.MessageComponentData().CustomID]; ok {
-				h(s, i)
+			if b.collect(s, i) {
+				return
+			}
+			if h, ok := b.componentHandler(customID); ok {
+				if !b.moduleEnabled(s, i, b.componentModule(customID)) {
+					return
+				}
+				b.runHandler(h, s, i)
 			}
 		}
 	})
-	err := b.s.Open()
+	err = b.s.Open()
 	if err != nil {
-		log.Fatalf("Cannot open the session: %v", err)
+		b.fatalf("Cannot open the session: %v", err)
 	}
 	defer func(s *discordgo.Session) {
 		err := s.Close()
 		if err != nil {
-			log.Fatalf("Cannot close session: %v", err)
+			b.fatalf("Cannot close session: %v", err)
 		}
 	}(b.s)
+	defer func(store storage.Store) {
+		err := store.Close()
+		if err != nil {
+			log.Printf("Cannot close the database: %v", err)
+		}
+	}(b.Store)
 
-	createdCommands, err := b.s.ApplicationCommandBulkOverwrite(b.s.State.User.ID, GUILD_ID, b.commands)
+	err = b.RegisterCommands()
 	if err != nil {
-		log.Fatalf("Cannot register commands: %v", err)
+		b.fatalf("Cannot register commands: %v", err)
+	}
+	b.StartedAt = time.Now()
+	if HEALTH_ADDR != "" {
+		b.serveHealth(HEALTH_ADDR)
 	}
 
+	b.Scheduler.Start(b.ctx)
+
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, os.Interrupt)
 	<-stop
 	log.Println("Gracefully shutting down")
 
+	b.cancel()
+	b.Scheduler.Stop()
+
 	if REMOVE_COMMANDS {
-		for _, cmd := range createdCommands {
+		for _, cmd := range b.createdCommands {
 			err := b.s.ApplicationCommandDelete(b.s.State.User.ID, GUILD_ID, cmd.ID)
 			if err != nil {
-				log.Fatalf("Cannot delete %q command: %v", cmd.Name, err)
+				b.fatalf("Cannot delete %q command: %v", cmd.Name, err)
 			}
 		}
 	}
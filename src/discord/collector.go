@@ -0,0 +1,139 @@
+package discord
+
+import (
+	"errors"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/NeuralNexusDev/neuralnexus-discord-bot/src/i18n"
+	"github.com/bwmarrin/discordgo"
+)
+
+// collectorBuffer interactions a collector holds before further ones go to the regular handlers
+const collectorBuffer = 8
+
+var (
+	// ErrCollectorTimeout no matching interaction arrived in time
+	ErrCollectorTimeout = errors.New("collector timed out")
+	// ErrCollectorStopped the collector was stopped or the bot is shutting down
+	ErrCollectorStopped = errors.New("collector stopped")
+)
+
+// CollectorFilter which component and modal interactions a collector receives, unset fields match anything
+type CollectorFilter struct {
+	// MessageID message the components belong to, or the modal was opened from
+	MessageID string
+	// UserID the only user whose interactions are collected, others are told the components aren't theirs
+	UserID string
+	// CustomIDPrefix custom ID prefix of the components or modal
+	CustomIDPrefix string
+}
+
+// Collector receives the component and modal interactions matching its filter, ahead of the regular handlers
+type Collector struct {
+	filter       CollectorFilter
+	interactions chan *discordgo.InteractionCreate
+	deadline     time.Time
+	stopped      chan struct{}
+	b            *Bot
+}
+
+// Collect starts collecting the interactions matching the filter until the timeout, call Stop when done
+func (b *Bot) Collect(filter CollectorFilter, timeout time.Duration) *Collector {
+	c := &Collector{
+		filter:       filter,
+		interactions: make(chan *discordgo.InteractionCreate, collectorBuffer),
+		deadline:     time.Now().Add(timeout),
+		stopped:      make(chan struct{}),
+		b:            b,
+	}
+	b.collectorsMu.Lock()
+	b.collectors = append(b.collectors, c)
+	b.collectorsMu.Unlock()
+	return c
+}
+
+// Await waits for the next interaction matching the filter.
+// The interaction isn't responded to, the caller must respond to it.
+func (b *Bot) Await(filter CollectorFilter, timeout time.Duration) (*discordgo.InteractionCreate, error) {
+	c := b.Collect(filter, timeout)
+	defer c.Stop()
+	return c.Next()
+}
+
+// Next waits for the next collected interaction, the caller must respond to it
+func (c *Collector) Next() (*discordgo.InteractionCreate, error) {
+	timer := time.NewTimer(time.Until(c.deadline))
+	defer timer.Stop()
+	select {
+	case i := <-c.interactions:
+		return i, nil
+	case <-timer.C:
+		c.Stop()
+		return nil, ErrCollectorTimeout
+	case <-c.stopped:
+		return nil, ErrCollectorStopped
+	case <-c.b.ctx.Done():
+		return nil, ErrCollectorStopped
+	}
+}
+
+// Stop stops collecting, further interactions go to the regular handlers
+func (c *Collector) Stop() {
+	c.b.collectorsMu.Lock()
+	defer c.b.collectorsMu.Unlock()
+	i := slices.Index(c.b.collectors, c)
+	if i < 0 {
+		return
+	}
+	c.b.collectors = slices.Delete(c.b.collectors, i, i+1)
+	close(c.stopped)
+}
+
+// matches checks the interaction against the filter, ignoring the user
+func (f CollectorFilter) matches(i *discordgo.InteractionCreate) bool {
+	var customID string
+	switch i.Type {
+	case discordgo.InteractionMessageComponent:
+		customID = i.MessageComponentData().CustomID
+	case discordgo.InteractionModalSubmit:
+		customID = i.ModalSubmitData().CustomID
+	default:
+		return false
+	}
+	if f.MessageID != "" && (i.Message == nil || i.Message.ID != f.MessageID) {
+		return false
+	}
+	return strings.HasPrefix(customID, f.CustomIDPrefix)
+}
+
+// collect hands the interaction to the first matching collector, returning false if none took it
+func (b *Bot) collect(s *discordgo.Session, i *discordgo.InteractionCreate) bool {
+	b.collectorsMu.Lock()
+	var wrongUser bool
+	for _, c := range b.collectors {
+		if time.Now().After(c.deadline) || !c.filter.matches(i) {
+			continue
+		}
+		if c.filter.UserID != "" && c.filter.UserID != InteractionUser(i).ID {
+			wrongUser = true
+			continue
+		}
+		select {
+		case c.interactions <- i:
+			b.collectorsMu.Unlock()
+			return true
+		default:
+			log.Printf("Collector for %+v is full, passing the interaction on", c.filter)
+		}
+	}
+	b.collectorsMu.Unlock()
+
+	if wrongUser {
+		respondEphemeral(b, s, i, ErrorEmbed(i.Locale, errors.New(i18n.T(i.Locale, "interaction.not_invoker"))))
+		return true
+	}
+	return false
+}
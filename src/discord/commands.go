@@ -0,0 +1,406 @@
+package discord
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/NeuralNexusDev/neuralnexus-discord-bot/src/i18n"
+	"github.com/bwmarrin/discordgo"
+)
+
+// maxAutocompleteChoices maximum number of choices in an autocomplete response
+const maxAutocompleteChoices = 25
+
+// NoOptions options struct for commands without options
+type NoOptions struct{}
+
+// CommandSpec declarative slash command, subcommand group or subcommand.
+// Leaf commands declare their options as a struct bound by Run, see BindOptions for the option tags.
+// Besides `option` and `default`, option fields may be tagged with `description:"..."`,
+// `choices:"a|b"` or `choices:"Name A=a|Name B=b"`, `min:"n"` and `max:"n"` (values for numbers, lengths for strings),
+// `channel:"text|voice|..."`, and the `autocomplete` option flag.
+type CommandSpec struct {
+	Name        string
+	Description string
+	// DMPermission allows the command in DMs, top level only
+	DMPermission bool
+	// DefaultMemberPermissions permissions required by default, top level only
+	DefaultMemberPermissions *int64
+	// Subcommands subcommands or subcommand groups, mutually exclusive with Run
+	Subcommands []*CommandSpec
+	// Run runs the command with its bound options
+	Run *CommandRunner
+	// Autocomplete returns the choices for the focused option of an autocomplete option
+	Autocomplete AutocompleteHandler
+}
+
+// CommandRunner runs a command with its options bound into a struct, see Run
+type CommandRunner struct {
+	options reflect.Type
+	run     func(b *Bot, s *discordgo.Session, i *discordgo.InteractionCreate, opts reflect.Value)
+}
+
+// AutocompleteHandler returns the choices for the focused option
+type AutocompleteHandler func(b *Bot, s *discordgo.Session, i *discordgo.InteractionCreate, focused *discordgo.ApplicationCommandInteractionDataOption) []*discordgo.ApplicationCommandOptionChoice
+
+// Run returns a runner that binds the command's options into a T before calling fn.
+// T's tagged fields also declare the command's options.
+func Run[T any](fn func(b *Bot, s *discordgo.Session, i *discordgo.InteractionCreate, opts *T)) *CommandRunner {
+	return &CommandRunner{
+		options: reflect.TypeFor[T](),
+		run: func(b *Bot, s *discordgo.Session, i *discordgo.InteractionCreate, opts reflect.Value) {
+			fn(b, s, i, opts.Interface().(*T))
+		},
+	}
+}
+
+// AddCommand adds the command generated from the spec along with its handler
+func (b *Bot) AddCommand(spec *CommandSpec) {
+	cmd, err := spec.ApplicationCommand()
+	if err != nil {
+		log.Fatalf("Invalid %q command: %v", spec.Name, err)
+	}
+	b.AddCommandHandler(cmd, spec.Handler(b))
+	if spec.hasAutocomplete() {
+		b.autocompleteHandlers[cmd.Name] = spec.AutocompleteHandler(b)
+	}
+}
+
+// AddCoreCommand adds a command generated from the spec that can't be disabled per guild
+func (b *Bot) AddCoreCommand(spec *CommandSpec) {
+	b.AddCommand(spec)
+	b.coreCommands[spec.Name] = true
+}
+
+// ApplicationCommand generates the command's definition
+func (c *CommandSpec) ApplicationCommand() (*discordgo.ApplicationCommand, error) {
+	options, err := c.options()
+	if err != nil {
+		return nil, err
+	}
+	return &discordgo.ApplicationCommand{
+		Name:                     c.Name,
+		NameLocalizations:        &map[discordgo.Locale]string{},
+		Description:              c.Description,
+		DescriptionLocalizations: &map[discordgo.Locale]string{},
+		Type:                     discordgo.ChatApplicationCommand,
+		DMPermission:             &c.DMPermission,
+		DefaultMemberPermissions: c.DefaultMemberPermissions,
+		Options:                  options,
+	}, nil
+}
+
+// Handler returns the handler running the invoked subcommand with its bound options
+func (c *CommandSpec) Handler(b *Bot) InteractionHandler {
+	return func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+		path, options := CommandOptions(i)
+		spec := c.find(path)
+		if spec == nil || spec.Run == nil {
+			b.ReportError(i, fmt.Errorf("no handler for /%s %s", c.Name, path))
+			b.RespondError(s, i, errors.New(i18n.T(i.Locale, "command.unknown_subcommand")))
+			return
+		}
+		opts := reflect.New(spec.Run.options)
+		err := BindOptions(s, options, opts.Interface())
+		if err != nil {
+			b.RespondValidationError(s, i, err)
+			return
+		}
+		spec.Run.run(b, s, i, opts)
+	}
+}
+
+// AutocompleteHandler returns the handler responding to autocomplete interactions of the invoked subcommand
+func (c *CommandSpec) AutocompleteHandler(b *Bot) InteractionHandler {
+	return func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+		path, options := CommandOptions(i)
+		spec := c.find(path)
+		if spec == nil || spec.Autocomplete == nil {
+			return
+		}
+		var focused *discordgo.ApplicationCommandInteractionDataOption
+		for _, o := range options {
+			if o.Focused {
+				focused = o
+			}
+		}
+		if focused == nil {
+			return
+		}
+
+		choices := spec.Autocomplete(b, s, i, focused)
+		if len(choices) > maxAutocompleteChoices {
+			choices = choices[:maxAutocompleteChoices]
+		}
+		err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionApplicationCommandAutocompleteResult,
+			Data: &discordgo.InteractionResponseData{
+				Choices: choices,
+			},
+		})
+		if err != nil {
+			log.Printf("Cannot respond to autocomplete for /%s %s: %v", c.Name, path, err)
+		}
+	}
+}
+
+// find returns the subcommand at the path, like "suggestion submit"
+func (c *CommandSpec) find(path string) *CommandSpec {
+	spec := c
+	for _, name := range strings.Fields(path) {
+		var next *CommandSpec
+		for _, sub := range spec.Subcommands {
+			if sub.Name == name {
+				next = sub
+				break
+			}
+		}
+		if next == nil {
+			return nil
+		}
+		spec = next
+	}
+	return spec
+}
+
+func (c *CommandSpec) hasAutocomplete() bool {
+	if c.Autocomplete != nil {
+		return true
+	}
+	for _, sub := range c.Subcommands {
+		if sub.hasAutocomplete() {
+			return true
+		}
+	}
+	return false
+}
+
+// options generates the options of the command, its subcommands or subcommand groups
+func (c *CommandSpec) options() ([]*discordgo.ApplicationCommandOption, error) {
+	if c.Run != nil && len(c.Subcommands) > 0 {
+		return nil, errors.New("commands can't both run and have subcommands")
+	}
+	if c.Run != nil {
+		return structOptions(c.Run.options)
+	}
+	if len(c.Subcommands) == 0 {
+		return nil, errors.New("commands need either Run or subcommands")
+	}
+
+	var options []*discordgo.ApplicationCommandOption
+	for _, sub := range c.Subcommands {
+		subOptions, err := sub.options()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", sub.Name, err)
+		}
+		typ := discordgo.ApplicationCommandOptionSubCommand
+		if len(sub.Subcommands) > 0 {
+			typ = discordgo.ApplicationCommandOptionSubCommandGroup
+		}
+		options = append(options, &discordgo.ApplicationCommandOption{
+			Name:                     sub.Name,
+			NameLocalizations:        map[discordgo.Locale]string{},
+			Description:              sub.Description,
+			DescriptionLocalizations: map[discordgo.Locale]string{},
+			Type:                     typ,
+			Options:                  subOptions,
+		})
+	}
+	return options, nil
+}
+
+var channelTypes = map[string]discordgo.ChannelType{
+	"text":     discordgo.ChannelTypeGuildText,
+	"voice":    discordgo.ChannelTypeGuildVoice,
+	"category": discordgo.ChannelTypeGuildCategory,
+	"news":     discordgo.ChannelTypeGuildNews,
+	"stage":    discordgo.ChannelTypeGuildStageVoice,
+	"forum":    discordgo.ChannelTypeGuildForum,
+	"thread":   discordgo.ChannelTypeGuildPublicThread,
+}
+
+// structOptions generates the options declared by the struct's tagged fields
+func structOptions(t reflect.Type) ([]*discordgo.ApplicationCommandOption, error) {
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("options must be a struct, not %s", t)
+	}
+
+	var options []*discordgo.ApplicationCommandOption
+	for idx := 0; idx < t.NumField(); idx++ {
+		field := t.Field(idx)
+		tag, ok := field.Tag.Lookup("option")
+		if !ok || !field.IsExported() {
+			continue
+		}
+		name, flags := parseOptionTag(tag)
+		typ, err := optionType(field.Type)
+		if err != nil {
+			return nil, fmt.Errorf("option %s: %w", name, err)
+		}
+		o := &discordgo.ApplicationCommandOption{
+			Name:                     name,
+			NameLocalizations:        map[discordgo.Locale]string{},
+			Description:              field.Tag.Get("description"),
+			DescriptionLocalizations: map[discordgo.Locale]string{},
+			Type:                     typ,
+			Required:                 flags["required"],
+			Autocomplete:             flags["autocomplete"],
+		}
+
+		if choices, ok := field.Tag.Lookup("choices"); ok {
+			for _, choice := range strings.Split(choices, "|") {
+				choiceName, value, ok := strings.Cut(choice, "=")
+				if !ok {
+					value = choiceName
+				}
+				v, err := choiceValue(typ, value)
+				if err != nil {
+					return nil, fmt.Errorf("option %s choice %q: %w", name, choice, err)
+				}
+				o.Choices = append(o.Choices, &discordgo.ApplicationCommandOptionChoice{
+					Name:              choiceName,
+					NameLocalizations: map[discordgo.Locale]string{},
+					Value:             v,
+				})
+			}
+		}
+		if minTag, ok := field.Tag.Lookup("min"); ok {
+			n, err := strconv.ParseFloat(minTag, 64)
+			if err != nil {
+				return nil, fmt.Errorf("option %s min: %w", name, err)
+			}
+			if typ == discordgo.ApplicationCommandOptionString {
+				o.MinLength = new(int)
+				*o.MinLength = int(n)
+			} else {
+				o.MinValue = &n
+			}
+		}
+		if maxTag, ok := field.Tag.Lookup("max"); ok {
+			n, err := strconv.ParseFloat(maxTag, 64)
+			if err != nil {
+				return nil, fmt.Errorf("option %s max: %w", name, err)
+			}
+			setOptionMax(o, n)
+		}
+		if channels, ok := field.Tag.Lookup("channel"); ok {
+			for _, c := range strings.Split(channels, "|") {
+				ct, ok := channelTypes[c]
+				if !ok {
+					return nil, fmt.Errorf("option %s: unknown channel type %q", name, c)
+				}
+				o.ChannelTypes = append(o.ChannelTypes, ct)
+			}
+		}
+		options = append(options, o)
+	}
+	return options, nil
+}
+
+// explicitMax options whose max value or length was set, discordgo can't tell a max of 0 apart from no max
+var explicitMax sync.Map // *discordgo.ApplicationCommandOption -> struct{}
+
+// setOptionMax sets the option's max value, or max length for string options, including a max of 0
+func setOptionMax(o *discordgo.ApplicationCommandOption, n float64) {
+	if o.Type == discordgo.ApplicationCommandOptionString {
+		o.MaxLength = int(n)
+	} else {
+		o.MaxValue = n
+	}
+	explicitMax.Store(o, struct{}{})
+}
+
+// optionMax returns the option's max value, or max length for string options, and whether it's set
+func optionMax(o *discordgo.ApplicationCommandOption) (float64, bool) {
+	_, set := explicitMax.Load(o)
+	if o.Type == discordgo.ApplicationCommandOptionString {
+		return float64(o.MaxLength), set || o.MaxLength != 0
+	}
+	return o.MaxValue, set || o.MaxValue != 0
+}
+
+// commandsPayload encodes the commands for registering, adding the max values of 0 discordgo omits
+func commandsPayload(commands []*discordgo.ApplicationCommand) ([]json.RawMessage, error) {
+	var payload []json.RawMessage
+	for _, cmd := range commands {
+		data, err := json.Marshal(cmd)
+		if err != nil {
+			return nil, err
+		}
+		var raw map[string]any
+		d := json.NewDecoder(bytes.NewReader(data))
+		d.UseNumber()
+		err = d.Decode(&raw)
+		if err != nil {
+			return nil, err
+		}
+		addZeroMax(cmd.Options, raw["options"])
+		data, err = json.Marshal(raw)
+		if err != nil {
+			return nil, err
+		}
+		payload = append(payload, data)
+	}
+	return payload, nil
+}
+
+// addZeroMax sets max_value in the encoded options for the options with a max value of 0
+func addZeroMax(options []*discordgo.ApplicationCommandOption, raw any) {
+	encoded, _ := raw.([]any)
+	for idx, o := range options {
+		if idx >= len(encoded) {
+			return
+		}
+		e, ok := encoded[idx].(map[string]any)
+		if !ok {
+			continue
+		}
+		if n, ok := optionMax(o); ok && n == 0 && o.Type != discordgo.ApplicationCommandOptionString {
+			e["max_value"] = 0
+		}
+		addZeroMax(o.Options, e["options"])
+	}
+}
+
+// optionType returns the option type of the field type
+func optionType(t reflect.Type) (discordgo.ApplicationCommandOptionType, error) {
+	switch t {
+	case userType:
+		return discordgo.ApplicationCommandOptionUser, nil
+	case channelType:
+		return discordgo.ApplicationCommandOptionChannel, nil
+	case roleType:
+		return discordgo.ApplicationCommandOptionRole, nil
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return discordgo.ApplicationCommandOptionString, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return discordgo.ApplicationCommandOptionInteger, nil
+	case reflect.Float32, reflect.Float64:
+		return discordgo.ApplicationCommandOptionNumber, nil
+	case reflect.Bool:
+		return discordgo.ApplicationCommandOptionBoolean, nil
+	}
+	return 0, fmt.Errorf("unsupported field type %s", t)
+}
+
+// choiceValue parses the choice value for the option type
+func choiceValue(typ discordgo.ApplicationCommandOptionType, value string) (any, error) {
+	switch typ {
+	case discordgo.ApplicationCommandOptionString:
+		return value, nil
+	case discordgo.ApplicationCommandOptionInteger:
+		return strconv.ParseInt(value, 10, 64)
+	case discordgo.ApplicationCommandOptionNumber:
+		return strconv.ParseFloat(value, 64)
+	}
+	return nil, errors.New("choices are only supported for strings and numbers")
+}
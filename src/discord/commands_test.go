@@ -0,0 +1,65 @@
+package discord
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+type boundedOptions struct {
+	Offset int    `option:"offset" description:"Offset" min:"-10" max:"0"`
+	Amount int    `option:"amount" description:"Amount" min:"1" max:"5"`
+	Query  string `option:"query" description:"Query" max:"20"`
+	Free   int    `option:"free" description:"Unbounded"`
+}
+
+func TestStructOptionsMax(t *testing.T) {
+	options, err := structOptions(reflect.TypeFor[boundedOptions]())
+	if err != nil {
+		t.Fatalf("structOptions() error = %v", err)
+	}
+	tests := []struct {
+		option string
+		max    float64
+		set    bool
+	}{
+		{"offset", 0, true},
+		{"amount", 5, true},
+		{"query", 20, true},
+		{"free", 0, false},
+	}
+	for idx, tt := range tests {
+		n, set := optionMax(options[idx])
+		if options[idx].Name != tt.option || n != tt.max || set != tt.set {
+			t.Errorf("optionMax(%s) = %v, %v, want %v, %v", options[idx].Name, n, set, tt.max, tt.set)
+		}
+	}
+}
+
+func TestCommandsPayloadKeepsZeroMax(t *testing.T) {
+	spec := &CommandSpec{Name: "bounded", Description: "Bounded", Run: Run(func(*Bot, *discordgo.Session, *discordgo.InteractionCreate, *boundedOptions) {})}
+	cmd, err := spec.ApplicationCommand()
+	if err != nil {
+		t.Fatalf("ApplicationCommand() error = %v", err)
+	}
+	payload, err := commandsPayload([]*discordgo.ApplicationCommand{cmd})
+	if err != nil {
+		t.Fatalf("commandsPayload() error = %v", err)
+	}
+
+	var sent struct {
+		Options []map[string]any `json:"options"`
+	}
+	if err := json.Unmarshal(payload[0], &sent); err != nil {
+		t.Fatalf("decoding payload: %v", err)
+	}
+	want := map[string]any{"offset": 0.0, "amount": 5.0, "query": nil, "free": nil}
+	for _, o := range sent.Options {
+		name := o["name"].(string)
+		if o["max_value"] != want[name] {
+			t.Errorf("%s max_value = %v, want %v", name, o["max_value"], want[name])
+		}
+	}
+}
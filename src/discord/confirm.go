@@ -0,0 +1,117 @@
+package discord
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/NeuralNexusDev/neuralnexus-discord-bot/src/i18n"
+	"github.com/bwmarrin/discordgo"
+)
+
+// DefaultConfirmTimeout how long confirmation dialogs wait if their timeout isn't set
+const DefaultConfirmTimeout = time.Minute
+
+// confirmComponentID custom ID of the confirmation dialog buttons, as "confirm:<dialog>:<yes|no>"
+const confirmComponentID = "confirm"
+
+// ConfirmDialog confirm/cancel prompt
+type ConfirmDialog struct {
+	Embed *discordgo.MessageEmbed
+	// ConfirmLabel confirm button label, "Confirm" if unset
+	ConfirmLabel string
+	// Timeout DefaultConfirmTimeout if unset
+	Timeout time.Duration
+}
+
+// Confirmation answer to a confirmation dialog
+type Confirmation struct {
+	// Confirmed whether the user confirmed, false if they cancelled or didn't answer in time
+	Confirmed bool
+	b         *Bot
+	s         *discordgo.Session
+	i         *discordgo.InteractionCreate
+}
+
+// Confirm shows the dialog and waits for the invoking user to confirm or cancel.
+// The dialog is an ephemeral message, so the message of a component stays as it was.
+// Cancelling or timing out updates the dialog to say so; after confirming, call Finish with the outcome.
+// The error is returned, and already reported, if the dialog couldn't be shown.
+func (b *Bot) Confirm(s *discordgo.Session, i *discordgo.InteractionCreate, d ConfirmDialog) (*Confirmation, error) {
+	c := &Confirmation{b: b, s: s, i: i}
+	timeout := d.Timeout
+	if timeout <= 0 {
+		timeout = DefaultConfirmTimeout
+	}
+	confirmLabel := d.ConfirmLabel
+	if confirmLabel == "" {
+		confirmLabel = i18n.T(i.Locale, "confirm.confirm")
+	}
+
+	dialogID := i.ID
+	collector := b.Collect(CollectorFilter{
+		UserID:         InteractionUser(i).ID,
+		CustomIDPrefix: confirmComponentID + ":" + dialogID + ":",
+	}, timeout)
+	defer collector.Stop()
+
+	response := &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Flags:  discordgo.MessageFlagsEphemeral,
+			Embeds: []*discordgo.MessageEmbed{d.Embed},
+			Components: []discordgo.MessageComponent{
+				ComponentActionRow(
+					discordgo.Button{Label: confirmLabel, Style: discordgo.DangerButton, CustomID: confirmComponentID + ":" + dialogID + ":yes"},
+					discordgo.Button{Label: i18n.T(i.Locale, "confirm.cancel"), Style: discordgo.SecondaryButton, CustomID: confirmComponentID + ":" + dialogID + ":no"},
+				),
+			},
+		},
+	}
+	err := s.InteractionRespond(i.Interaction, response)
+	if err != nil {
+		b.ReportError(i, err)
+		return c, err
+	}
+
+	answer, err := collector.Next()
+	if errors.Is(err, ErrCollectorTimeout) {
+		c.Finish(SimpleEmbed(d.Embed.Title, i18n.T(i.Locale, "confirm.timed_out"), EMBED_YELLOW))
+		return c, nil
+	} else if err != nil {
+		return c, nil
+	}
+
+	c.Confirmed = strings.HasSuffix(answer.MessageComponentData().CustomID, ":yes")
+	if c.Confirmed {
+		err = s.InteractionRespond(answer.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseDeferredMessageUpdate,
+		})
+	} else {
+		err = s.InteractionRespond(answer.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseUpdateMessage,
+			Data: &discordgo.InteractionResponseData{
+				Embeds:     []*discordgo.MessageEmbed{SimpleEmbed(d.Embed.Title, i18n.T(i.Locale, "confirm.cancelled"), EMBED_YELLOW)},
+				Components: []discordgo.MessageComponent{},
+			},
+		})
+	}
+	if err != nil {
+		b.ReportError(answer, err)
+	}
+	return c, nil
+}
+
+// Finish updates the dialog's message to show the outcome
+func (c *Confirmation) Finish(embed *discordgo.MessageEmbed, components ...discordgo.MessageComponent) {
+	c.b.EditResponse(c.s, c.i, embed, components...)
+}
+
+// expireConfirmation disables the buttons of dialogs that are no longer waiting for an answer
+func (b *Bot) expireConfirmation(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	var components []discordgo.MessageComponent
+	if i.Message != nil {
+		components = DisableComponents(i.Message.Components)
+	}
+	updateMessage(b, s, i, &discordgo.InteractionResponseData{Components: components})
+}
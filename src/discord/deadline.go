@@ -0,0 +1,39 @@
+package discord
+
+import (
+	"context"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Interaction response windows
+const (
+	// InitialResponseWindow time Discord gives to respond to an interaction
+	InitialResponseWindow = 3 * time.Second
+	// FollowupWindow time the interaction's token can edit its response and send followups
+	FollowupWindow = 15 * time.Minute
+	// responseMargin time left to send the response itself once the work is done
+	responseMargin = 500 * time.Millisecond
+)
+
+// ResponseContext returns a context for work done before the interaction's initial response,
+// cancelled when Discord would no longer accept the response or the bot shuts down
+func (b *Bot) ResponseContext(i *discordgo.InteractionCreate) (context.Context, context.CancelFunc) {
+	return context.WithDeadline(b.interactionContext(i), interactionCreated(i).Add(InitialResponseWindow-responseMargin))
+}
+
+// FollowupContext returns a context for work done after deferring or responding to the interaction,
+// cancelled when its token expires or the bot shuts down
+func (b *Bot) FollowupContext(i *discordgo.InteractionCreate) (context.Context, context.CancelFunc) {
+	return context.WithDeadline(b.interactionContext(i), interactionCreated(i).Add(FollowupWindow-responseMargin))
+}
+
+// interactionCreated returns when the interaction was created, from its ID
+func interactionCreated(i *discordgo.InteractionCreate) time.Time {
+	created, err := discordgo.SnowflakeTimestamp(i.ID)
+	if err != nil {
+		return time.Now()
+	}
+	return created
+}
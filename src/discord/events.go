@@ -0,0 +1,67 @@
+package discord
+
+import (
+	"log"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// baseIntents intents the bot always requests, interactions themselves don't need any
+const baseIntents = discordgo.IntentsGuilds
+
+// privilegedIntents intents that must also be enabled in the Discord developer portal
+const privilegedIntents = discordgo.IntentsGuildMembers | discordgo.IntentsGuildPresences | discordgo.IntentsMessageContent
+
+// AddEventHandler adds a gateway event handler, any handler func accepted by discordgo.Session.AddHandler,
+// along with the intents the bot needs to receive its events. Handlers must be added before Start, which
+// identifies with the requested intents. It returns a function removing the handler.
+func (b *Bot) AddEventHandler(handler interface{}, intents discordgo.Intent) func() {
+	log.Printf("Adding %T event handler", handler)
+
+	b.RequestIntents(intents)
+	return b.s.AddHandler(handler)
+}
+
+// RequestIntents adds gateway intents the bot identifies with, for events received through other handlers
+func (b *Bot) RequestIntents(intents discordgo.Intent) {
+	if p := intents & privilegedIntents &^ b.intents; p != 0 {
+		log.Printf("Requesting privileged intents %d, these must be enabled for the application", p)
+	}
+	b.intents |= intents
+}
+
+// OnGuildCreate adds a handler for when the bot joins a guild, or a guild becomes available
+func (b *Bot) OnGuildCreate(h func(s *discordgo.Session, e *discordgo.GuildCreate)) {
+	b.AddEventHandler(h, discordgo.IntentsGuilds)
+}
+
+// OnGuildDelete adds a handler for when the bot leaves a guild, or a guild becomes unavailable
+func (b *Bot) OnGuildDelete(h func(s *discordgo.Session, e *discordgo.GuildDelete)) {
+	b.AddEventHandler(h, discordgo.IntentsGuilds)
+}
+
+// OnGuildMemberAdd adds a handler for members joining a guild, requires the privileged guild members intent
+func (b *Bot) OnGuildMemberAdd(h func(s *discordgo.Session, e *discordgo.GuildMemberAdd)) {
+	b.AddEventHandler(h, discordgo.IntentsGuildMembers)
+}
+
+// OnGuildMemberRemove adds a handler for members leaving a guild, requires the privileged guild members intent
+func (b *Bot) OnGuildMemberRemove(h func(s *discordgo.Session, e *discordgo.GuildMemberRemove)) {
+	b.AddEventHandler(h, discordgo.IntentsGuildMembers)
+}
+
+// OnMessageCreate adds a handler for messages in guilds and DMs.
+// Message content is only included if the handler also requests discordgo.IntentsMessageContent.
+func (b *Bot) OnMessageCreate(h func(s *discordgo.Session, e *discordgo.MessageCreate)) {
+	b.AddEventHandler(h, discordgo.IntentsGuildMessages|discordgo.IntentsDirectMessages)
+}
+
+// OnMessageReactionAdd adds a handler for reactions added in guilds and DMs
+func (b *Bot) OnMessageReactionAdd(h func(s *discordgo.Session, e *discordgo.MessageReactionAdd)) {
+	b.AddEventHandler(h, discordgo.IntentsGuildMessageReactions|discordgo.IntentsDirectMessageReactions)
+}
+
+// OnMessageReactionRemove adds a handler for reactions removed in guilds and DMs
+func (b *Bot) OnMessageReactionRemove(h func(s *discordgo.Session, e *discordgo.MessageReactionRemove)) {
+	b.AddEventHandler(h, discordgo.IntentsGuildMessageReactions|discordgo.IntentsDirectMessageReactions)
+}
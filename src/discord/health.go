@@ -0,0 +1,89 @@
+package discord
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"expvar"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/NeuralNexusDev/neuralnexus-discord-bot/src/api"
+)
+
+// healthShutdownTimeout how long the health server waits for requests in flight when shutting down
+const healthShutdownTimeout = 5 * time.Second
+
+// Health statuses
+const (
+	HealthOK       = "ok"
+	HealthDegraded = "degraded"
+	HealthDown     = "down"
+)
+
+// Health bot health as reported to health checks
+type Health struct {
+	// Status HealthDown if the gateway isn't connected, HealthDegraded if the NeuralNexus API is unavailable
+	Status  string           `json:"status"`
+	Gateway bool             `json:"gateway"`
+	Uptime  string           `json:"uptime"`
+	API     api.BreakerStats `json:"api"`
+}
+
+// Health returns the bot's health
+func (b *Bot) Health() Health {
+	b.s.RLock()
+	ready := b.s.DataReady
+	b.s.RUnlock()
+	h := Health{
+		Status:  HealthOK,
+		Gateway: ready,
+		API:     b.API.Breaker.Stats(),
+	}
+	if !b.StartedAt.IsZero() {
+		h.Uptime = time.Since(b.StartedAt).Round(time.Second).String()
+	}
+	if b.API.Breaker.State() != api.BreakerClosed {
+		h.Status = HealthDegraded
+	}
+	if !h.Gateway {
+		h.Status = HealthDown
+	}
+	return h
+}
+
+// serveHealth serves the health check at /healthz and the metrics at /debug/vars until the bot shuts down
+func (b *Bot) serveHealth(addr string) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, r *http.Request) {
+		h := b.Health()
+		w.Header().Set("Content-Type", "application/json")
+		if h.Status == HealthDown {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		err := json.NewEncoder(w).Encode(h)
+		if err != nil {
+			log.Printf("Cannot write health check response: %v", err)
+		}
+	})
+	mux.Handle("GET /debug/vars", expvar.Handler())
+
+	server := &http.Server{Addr: addr, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		log.Printf("Serving health checks on %s", addr)
+		err := server.ListenAndServe()
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("Cannot serve health checks: %v", err)
+		}
+	}()
+	go func() {
+		<-b.ctx.Done()
+		ctx, cancel := context.WithTimeout(context.Background(), healthShutdownTimeout)
+		defer cancel()
+		err := server.Shutdown(ctx)
+		if err != nil {
+			log.Printf("Cannot shut down the health server: %v", err)
+		}
+	}()
+}
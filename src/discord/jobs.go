@@ -0,0 +1,54 @@
+package discord
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"github.com/NeuralNexusDev/neuralnexus-discord-bot/src/scheduler"
+	"github.com/NeuralNexusDev/neuralnexus-discord-bot/src/storage"
+)
+
+// JobRun last run of a scheduled job
+type JobRun struct {
+	Name    string    `json:"name"`
+	LastRun time.Time `json:"last_run"`
+}
+
+// JobRunRepository scheduler.LastRunStore backed by storage
+type JobRunRepository struct {
+	repo *storage.Repository[JobRun]
+}
+
+// NewJobRunRepository returns a job run repository backed by the store
+func NewJobRunRepository(store storage.Store) *JobRunRepository {
+	return &JobRunRepository{
+		repo: storage.NewRepository[JobRun](store, JobRunsBucket),
+	}
+}
+
+// LastRun returns when the job last ran, or the zero time if it never has
+func (r *JobRunRepository) LastRun(name string) (time.Time, error) {
+	run, err := r.repo.Get(name)
+	if errors.Is(err, storage.ErrNotFound) {
+		return time.Time{}, nil
+	} else if err != nil {
+		return time.Time{}, err
+	}
+	return run.LastRun, nil
+}
+
+// SetLastRun records when the job last ran
+func (r *JobRunRepository) SetLastRun(name string, t time.Time) error {
+	return r.repo.Put(name, &JobRun{Name: name, LastRun: t})
+}
+
+// AddJob registers a job with the bot's scheduler
+func (b *Bot) AddJob(job scheduler.Job) {
+	log.Printf("Adding job %q", job.Name)
+
+	err := b.Scheduler.Add(job)
+	if err != nil {
+		log.Fatalf("Cannot add job: %v", err)
+	}
+}
@@ -0,0 +1,35 @@
+package discord
+
+import "github.com/NeuralNexusDev/neuralnexus-discord-bot/src/storage"
+
+// Bucket names used by the bot's repositories
+const (
+	GuildSettingsBucket = "guild_settings"
+	JobRunsBucket       = "job_runs"
+	AuditLogBucket      = "audit_log"
+)
+
+// migrations the bot's storage schema, append new migrations to the end
+var migrations = []storage.Migration{
+	{
+		Version: 1,
+		Name:    "create guild settings bucket",
+		Up: func(tx storage.Tx) error {
+			return tx.CreateBucket(GuildSettingsBucket)
+		},
+	},
+	{
+		Version: 2,
+		Name:    "create job runs bucket",
+		Up: func(tx storage.Tx) error {
+			return tx.CreateBucket(JobRunsBucket)
+		},
+	},
+	{
+		Version: 3,
+		Name:    "create audit log bucket",
+		Up: func(tx storage.Tx) error {
+			return tx.CreateBucket(AuditLogBucket)
+		},
+	},
+}
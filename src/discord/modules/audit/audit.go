@@ -0,0 +1,102 @@
+package audit
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	bot "github.com/NeuralNexusDev/neuralnexus-discord-bot/src/discord"
+	"github.com/NeuralNexusDev/neuralnexus-discord-bot/src/i18n"
+	"github.com/bwmarrin/discordgo"
+)
+
+// AuditCommand audit log search command
+var AuditCommand = &bot.CommandSpec{
+	Name:                     "audit",
+	Description:              "Search this server's audit log",
+	DefaultMemberPermissions: &bot.ManageServerPermission,
+	Run:                      bot.Run(auditSearch),
+	Autocomplete:             actionAutocomplete,
+}
+
+// searchOptions audit log search command options
+type searchOptions struct {
+	User   *discordgo.User `option:"user" description:"Only show actions taken by this user"`
+	Action string          `option:"action,autocomplete" description:"Only show actions starting with this, e.g. beename or config.module"`
+	Limit  int             `option:"limit" description:"How many entries to show" min:"1" max:"25" default:"10"`
+}
+
+func auditSearch(b *bot.Bot, s *discordgo.Session, i *discordgo.InteractionCreate, opts *searchOptions) {
+	var embed *discordgo.MessageEmbed
+	if i.GuildID == "" {
+		embed = bot.ErrorEmbed(i.Locale, errors.New(i18n.T(i.Locale, "config.guild_only")))
+	} else {
+		embed = search(b, i, opts)
+	}
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Flags:  discordgo.MessageFlagsEphemeral,
+			Embeds: []*discordgo.MessageEmbed{embed},
+		},
+	})
+	if err != nil {
+		b.ReportError(i, err)
+		return
+	}
+}
+
+// search runs the audit log search and returns the response embed
+func search(b *bot.Bot, i *discordgo.InteractionCreate, opts *searchOptions) *discordgo.MessageEmbed {
+	filter := bot.AuditFilter{
+		GuildID: i.GuildID,
+		Action:  strings.TrimSpace(opts.Action),
+		Limit:   opts.Limit,
+	}
+	if opts.User != nil {
+		filter.ActorID = opts.User.ID
+	}
+
+	entries, err := b.Audit.Search(filter)
+	if err != nil {
+		b.ReportError(i, fmt.Errorf("searching audit log: %w", err))
+		return bot.ErrorEmbed(i.Locale, errors.New(i18n.T(i.Locale, "audit.search_failed")))
+	}
+	if len(entries) == 0 {
+		return bot.SimpleEmbed(i18n.T(i.Locale, "audit.title"), i18n.T(i.Locale, "audit.empty"), bot.EMBED_YELLOW)
+	}
+
+	var lines []string
+	for _, e := range entries {
+		lines = append(lines, bot.AuditLine(e))
+	}
+	return bot.SimpleEmbed(i18n.T(i.Locale, "audit.title"), strings.Join(lines, "\n"), bot.EMBED_GREEN)
+}
+
+// actionAutocomplete suggests the actions recorded in the guild's audit log
+func actionAutocomplete(b *bot.Bot, s *discordgo.Session, i *discordgo.InteractionCreate, focused *discordgo.ApplicationCommandInteractionDataOption) []*discordgo.ApplicationCommandOptionChoice {
+	if i.GuildID == "" || focused.Name != "action" {
+		return nil
+	}
+	entries, err := b.Audit.Search(bot.AuditFilter{
+		GuildID: i.GuildID,
+		Action:  strings.TrimSpace(focused.StringValue()),
+		Limit:   100,
+	})
+	if err != nil {
+		log.Printf("Cannot search the audit log for actions: %v", err)
+		return nil
+	}
+
+	var choices []*discordgo.ApplicationCommandOptionChoice
+	seen := map[string]bool{}
+	for _, e := range entries {
+		if seen[e.Action] {
+			continue
+		}
+		seen[e.Action] = true
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{Name: e.Action, Value: e.Action})
+	}
+	return choices
+}
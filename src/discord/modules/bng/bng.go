@@ -1,14 +1,28 @@
 package bng
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"log"
 
 	"github.com/NeuralNexusDev/neuralnexus-discord-bot/src/api"
 	bot "github.com/NeuralNexusDev/neuralnexus-discord-bot/src/discord"
+	"github.com/NeuralNexusDev/neuralnexus-discord-bot/src/i18n"
 	"github.com/bwmarrin/discordgo"
 )
 
+// BeeNamePermission permission needed to upload and delete bee names and moderate suggestions
+const BeeNamePermission = "beenamegenerator|*"
+
+// Audit log actions
+const (
+	ActionUpload           = "beename.upload"
+	ActionDelete           = "beename.delete"
+	ActionAcceptSuggestion = "beename.suggestion.accept"
+	ActionRejectSuggestion = "beename.suggestion.reject"
+)
+
 // BeeNameSuggestionAcceptButton bee name suggestion accept button
 var BeeNameSuggestionAcceptButton = discordgo.Button{
 	Label:    "Accept",
@@ -33,259 +47,367 @@ var BeeNameSuggestionNextButton = discordgo.Button{
 	CustomID: "beename_suggestion_next",
 }
 
-// BeeNameComponentHandlers bee name component handlers
-var BeeNameComponentHandlers = map[string]bot.InteractionHandler{
-	"beename_suggestion_accept": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
-		log.Println("Handling beename_suggestion_accept")
-
-		var embed *discordgo.MessageEmbed
-		name := i.Message.Embeds[0].Description
-		err := api.AcceptBeeNameSuggestion(name)
-		if err != nil {
-			embed = bot.ErrorEmbed(err)
-		} else {
-			embed = bot.SimpleEmbed("Accepted", name, bot.EMBED_GREEN)
-		}
+// localizedButton returns a copy of the button with its label in the given locale
+func localizedButton(locale discordgo.Locale, button discordgo.Button, key string) discordgo.Button {
+	button.Label = i18n.T(locale, key)
+	return button
+}
 
-		err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-			Type: discordgo.InteractionResponseChannelMessageWithSource,
-			Data: &discordgo.InteractionResponseData{
-				Flags:      discordgo.MessageFlagsEphemeral,
-				Embeds:     []*discordgo.MessageEmbed{embed},
-				Components: []discordgo.MessageComponent{bot.ComponentActionRow(BeeNameSuggestionNextButton)},
-			},
-		})
-		if err != nil {
-			log.Printf("Error handling button interaction: %s\n", err)
-			return
-		}
-	},
-	"beename_suggestion_reject": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
-		log.Println("Handling beename_suggestion_reject")
-
-		var embed *discordgo.MessageEmbed
-		name := i.Message.Embeds[0].Description
-		err := api.RejectBeeNameSuggestion(name)
-		if err != nil {
-			embed = bot.ErrorEmbed(err)
-		} else {
-			embed = bot.SimpleEmbed("Rejected", name, bot.EMBED_RED)
-		}
+func acceptButton(locale discordgo.Locale) discordgo.Button {
+	return localizedButton(locale, BeeNameSuggestionAcceptButton, "beename.button.accept")
+}
 
-		err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-			Type: discordgo.InteractionResponseChannelMessageWithSource,
-			Data: &discordgo.InteractionResponseData{
-				Flags:      discordgo.MessageFlagsEphemeral,
-				Embeds:     []*discordgo.MessageEmbed{embed},
-				Components: []discordgo.MessageComponent{bot.ComponentActionRow(BeeNameSuggestionNextButton)},
-			},
-		})
-		if err != nil {
-			log.Printf("Error handling button interaction: %s\n", err)
-			return
-		}
-	},
-	"beename_suggestion_next": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
-		log.Println("Handling beename_suggestion_next")
-
-		var embed *discordgo.MessageEmbed
-		suggestions, err := api.GetBeeNameSuggestions()
-		if err != nil {
-			embed = bot.ErrorEmbed(err)
-		} else if len(suggestions.Suggestions) == 0 {
-			embed = bot.SimpleEmbed("Bee Name Suggestions", "No suggestions available", bot.EMBED_YELLOW)
-			err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-				Type: discordgo.InteractionResponseUpdateMessage,
+func rejectButton(locale discordgo.Locale) discordgo.Button {
+	return localizedButton(locale, BeeNameSuggestionRejectButton, "beename.button.reject")
+}
+
+func nextButton(locale discordgo.Locale) discordgo.Button {
+	return localizedButton(locale, BeeNameSuggestionNextButton, "beename.button.next")
+}
+
+// BeeNameComponentHandlers bee name component handlers
+func BeeNameComponentHandlers(b *bot.Bot) map[string]bot.InteractionHandler {
+	return map[string]bot.InteractionHandler{
+		"beename_suggestion_accept": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+			log.Println("Handling beename_suggestion_accept")
+
+			// Deferred, the permission check and accepting can take longer than the initial response window
+			name := i.Message.Embeds[0].Description
+			err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+				Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
 				Data: &discordgo.InteractionResponseData{
-					Flags:      discordgo.MessageFlagsEphemeral,
-					Embeds:     []*discordgo.MessageEmbed{embed},
-					Components: []discordgo.MessageComponent{bot.ComponentActionRow(BeeNameSuggestionNextButton)},
+					Flags: discordgo.MessageFlagsEphemeral,
 				},
 			})
 			if err != nil {
-				log.Printf("Error handling button interaction: %s\n", err)
+				b.ReportError(i, err)
 				return
 			}
-		} else {
-			embed = bot.SimpleEmbed("Bee Name Suggestions", suggestions.Suggestions[0], bot.EMBED_GREEN)
-		}
 
-		err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-			Type: discordgo.InteractionResponseUpdateMessage,
-			Data: &discordgo.InteractionResponseData{
-				Flags:      discordgo.MessageFlagsEphemeral,
-				Embeds:     []*discordgo.MessageEmbed{embed},
-				Components: []discordgo.MessageComponent{bot.ComponentActionRow(BeeNameSuggestionNextButton, BeeNameSuggestionAcceptButton, BeeNameSuggestionRejectButton)},
-			},
-		})
-		if err != nil {
-			log.Printf("Error handling button interaction: %s\n", err)
-			return
-		}
-	},
-}
+			ctx, cancel := b.FollowupContext(i)
+			defer cancel()
+			show := func(embed *discordgo.MessageEmbed) { b.EditResponse(s, i, embed) }
+			if !checkCanManageNames(ctx, b, i, ActionAcceptSuggestion, name, "beename.suggestion.no_permission", show) {
+				return
+			}
 
-// BeeNameCommand bee name command
-var BeeNameCommand = &discordgo.ApplicationCommand{
-	Name:                     "beename",
-	NameLocalizations:        &map[discordgo.Locale]string{},
-	Description:              "Generate a bee name",
-	DescriptionLocalizations: &map[discordgo.Locale]string{},
-	Type:                     discordgo.ChatApplicationCommand,
-	DMPermission:             &bot.DMPermissionTrue,
-	Options: []*discordgo.ApplicationCommandOption{
-		{
-			Name:                     "get",
-			NameLocalizations:        map[discordgo.Locale]string{},
-			Description:              "Generate a bee name",
-			DescriptionLocalizations: map[discordgo.Locale]string{},
-			Type:                     discordgo.ApplicationCommandOptionSubCommand,
-		},
-		{
-			Name:                     "upload",
-			NameLocalizations:        map[discordgo.Locale]string{},
-			Description:              "Upload a bee name",
-			DescriptionLocalizations: map[discordgo.Locale]string{},
-			Type:                     discordgo.ApplicationCommandOptionSubCommand,
-			Options: []*discordgo.ApplicationCommandOption{
-				{
-					Name:                     "name",
-					NameLocalizations:        map[discordgo.Locale]string{},
-					Description:              "The bee name to upload",
-					DescriptionLocalizations: map[discordgo.Locale]string{},
-					Required:                 true,
-					Type:                     discordgo.ApplicationCommandOptionString,
-				},
-			},
-		},
-		{
-			Name:                     "suggestion",
-			NameLocalizations:        map[discordgo.Locale]string{},
-			Description:              "Suggestion command group",
-			DescriptionLocalizations: map[discordgo.Locale]string{},
-			Type:                     discordgo.ApplicationCommandOptionSubCommandGroup,
-			Options: []*discordgo.ApplicationCommandOption{
-				{
-					Name:                     "get",
-					NameLocalizations:        map[discordgo.Locale]string{},
-					Description:              "Get a list of bee name suggestions",
-					DescriptionLocalizations: map[discordgo.Locale]string{},
-					Type:                     discordgo.ApplicationCommandOptionSubCommand,
-				},
-				{
-					Name:                     "submit",
-					NameLocalizations:        map[discordgo.Locale]string{},
-					Description:              "Submit a bee name suggestion",
-					DescriptionLocalizations: map[discordgo.Locale]string{},
-					Type:                     discordgo.ApplicationCommandOptionSubCommand,
-					Options: []*discordgo.ApplicationCommandOption{
-						{
-							Name:                     "name",
-							NameLocalizations:        map[discordgo.Locale]string{},
-							Description:              "The bee name suggestion",
-							DescriptionLocalizations: map[discordgo.Locale]string{},
-							Required:                 true,
-							Type:                     discordgo.ApplicationCommandOptionString,
-						},
-					},
-				},
-			},
+			var embed *discordgo.MessageEmbed
+			err = b.API.AcceptBeeNameSuggestion(ctx, name)
+			if err != nil {
+				b.ReportError(i, err)
+				embed = bot.ErrorEmbed(i.Locale, err)
+			} else {
+				embed = bot.SimpleEmbed(i18n.T(i.Locale, "beename.suggestion.accepted"), name, bot.EMBED_GREEN)
+			}
+			b.EditResponse(s, i, embed, bot.ComponentActionRow(nextButton(i.Locale)))
+			b.Audit.Record(i, ActionAcceptSuggestion, name, err)
 		},
-	},
-}
+		"beename_suggestion_reject": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+			log.Println("Handling beename_suggestion_reject")
 
-// BeeNameCommandHandler bee name command handler
-func BeeNameCommandHandler(s *discordgo.Session, i *discordgo.InteractionCreate) {
-	var embed *discordgo.MessageEmbed
-	options := i.ApplicationCommandData().Options
-	switch options[0].Name {
-	case "get":
-		name, err := api.GetBeeName()
-		if err != nil {
-			embed = bot.ErrorEmbed(err)
-		} else {
-			embed = bot.SimpleEmbed("Bee Name", name.Name, bot.EMBED_GREEN)
-		}
-	case "upload":
-		user, err := api.GetUserFromPlatform("discord", i.Member.User.ID)
-		if err != nil {
-			user, err = api.UpdateUserPlatform("discord", i.Member.User.ID, i.Member.User)
-			if err != nil {
-				embed = bot.ErrorEmbed(err)
-				break
+			// Permissions are checked once confirmed, the check can take longer than the initial response window
+			name := i.Message.Embeds[0].Description
+			confirmation, err := b.Confirm(s, i, bot.ConfirmDialog{
+				Embed:        bot.SimpleEmbed(i18n.T(i.Locale, "beename.suggestion.reject.confirm.title"), i18n.T(i.Locale, "beename.suggestion.reject.confirm", name), bot.EMBED_YELLOW),
+				ConfirmLabel: i18n.T(i.Locale, "beename.button.reject"),
+			})
+			if err != nil || !confirmation.Confirmed {
+				return
 			}
-		}
-		if !user.HasPermission("beenamegenerator|*") {
-			embed = bot.ErrorEmbed(errors.New("you do not have permission to upload a bee name"))
-			break
-		}
 
-		name := options[0].Options[0].StringValue()
-		err = api.UploadBeeName(name)
-		embed = bot.ErrorSuccessEmbed(err, "Bee name uploaded")
-	case "delete":
-		user, err := api.GetUserFromPlatform("discord", i.Member.User.ID)
-		if err != nil {
-			user, err = api.UpdateUserPlatform("discord", i.Member.User.ID, i.Member.User)
+			ctx, cancel := b.FollowupContext(i)
+			defer cancel()
+			show := func(embed *discordgo.MessageEmbed) { confirmation.Finish(embed) }
+			if !checkCanManageNames(ctx, b, i, ActionRejectSuggestion, name, "beename.suggestion.no_permission", show) {
+				return
+			}
+
+			var embed *discordgo.MessageEmbed
+			err = b.API.RejectBeeNameSuggestion(ctx, name)
 			if err != nil {
-				bot.ErrorEmbed(err)
-				break
+				b.ReportError(i, err)
+				embed = bot.ErrorEmbed(i.Locale, err)
+			} else {
+				embed = bot.SimpleEmbed(i18n.T(i.Locale, "beename.suggestion.rejected"), name, bot.EMBED_RED)
 			}
-		}
-		if !user.HasPermission("beenamegenerator|*") {
-			embed = bot.ErrorEmbed(errors.New("you do not have permission to delete a bee name"))
-			break
-		}
+			confirmation.Finish(embed, bot.ComponentActionRow(nextButton(i.Locale)))
+			b.Audit.Record(i, ActionRejectSuggestion, name, err)
+		},
+		"beename_suggestion_next": func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+			log.Println("Handling beename_suggestion_next")
+
+			ctx, cancel := b.ResponseContext(i)
+			defer cancel()
 
-		name := options[0].Options[0].StringValue()
-		err = api.DeleteBeeName(name)
-		embed = bot.ErrorSuccessEmbed(err, "Bee name deleted")
-	case "suggestion":
-		switch options[0].Options[0].Name {
-		case "get":
-			suggestions, err := api.GetBeeNameSuggestions()
+			var embed *discordgo.MessageEmbed
+			suggestions, err := b.API.GetBeeNameSuggestions(ctx)
 			if err != nil {
-				embed = bot.ErrorEmbed(err)
-				break
+				b.ReportError(i, err)
+				embed = bot.ErrorEmbed(i.Locale, err)
 			} else if len(suggestions.Suggestions) == 0 {
-				embed = bot.SimpleEmbed("Bee Name Suggestions", "No suggestions available", bot.EMBED_GREEN)
-				err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-					Type: discordgo.InteractionResponseChannelMessageWithSource,
+				embed = bot.SimpleEmbed(i18n.T(i.Locale, "beename.suggestions.title"), i18n.T(i.Locale, "beename.suggestions.empty"), bot.EMBED_YELLOW)
+				err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+					Type: discordgo.InteractionResponseUpdateMessage,
 					Data: &discordgo.InteractionResponseData{
 						Flags:      discordgo.MessageFlagsEphemeral,
 						Embeds:     []*discordgo.MessageEmbed{embed},
-						Components: []discordgo.MessageComponent{bot.ComponentActionRow(BeeNameSuggestionNextButton)},
+						Components: []discordgo.MessageComponent{bot.ComponentActionRow(nextButton(i.Locale))},
 					},
 				})
 				if err != nil {
-					log.Printf("Error handling command interaction: %s", err)
+					b.ReportError(i, err)
 					return
 				}
-				return
+			} else {
+				embed = bot.SimpleEmbed(i18n.T(i.Locale, "beename.suggestions.title"), suggestions.Suggestions[0], bot.EMBED_GREEN)
 			}
-			embed = bot.SimpleEmbed("Bee Name Suggestions", suggestions.Suggestions[0], bot.EMBED_GREEN)
 
 			err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-				Type: discordgo.InteractionResponseChannelMessageWithSource,
+				Type: discordgo.InteractionResponseUpdateMessage,
 				Data: &discordgo.InteractionResponseData{
-					Flags:  discordgo.MessageFlagsEphemeral,
-					Embeds: []*discordgo.MessageEmbed{embed},
-					Components: []discordgo.MessageComponent{
-						bot.ComponentActionRow(BeeNameSuggestionNextButton, BeeNameSuggestionAcceptButton, BeeNameSuggestionRejectButton),
-					},
+					Flags:      discordgo.MessageFlagsEphemeral,
+					Embeds:     []*discordgo.MessageEmbed{embed},
+					Components: []discordgo.MessageComponent{bot.ComponentActionRow(nextButton(i.Locale), acceptButton(i.Locale), rejectButton(i.Locale))},
 				},
 			})
 			if err != nil {
-				log.Printf("Error handling command interaction: %s", err)
+				b.ReportError(i, err)
 				return
 			}
-			return
-		case "submit":
-			err := api.SubmitBeeNameSuggestion(options[0].Options[0].Options[0].StringValue())
-			embed = bot.ErrorSuccessEmbed(err, "Bee name suggestion submitted")
+		},
+	}
+}
+
+// BeeNameCommand bee name command
+var BeeNameCommand = &bot.CommandSpec{
+	Name:         "beename",
+	Description:  "Generate a bee name",
+	DMPermission: true,
+	Subcommands: []*bot.CommandSpec{
+		{
+			Name:        "get",
+			Description: "Generate a bee name",
+			Run:         bot.Run(getName),
+		},
+		{
+			Name:        "upload",
+			Description: "Upload a bee name",
+			Run:         bot.Run(uploadName),
+		},
+		{
+			Name:        "delete",
+			Description: "Delete a bee name",
+			Run:         bot.Run(deleteName),
+		},
+		{
+			Name:        "suggestion",
+			Description: "Suggestion command group",
+			Subcommands: []*bot.CommandSpec{
+				{
+					Name:        "get",
+					Description: "Get a list of bee name suggestions",
+					Run:         bot.Run(getSuggestions),
+				},
+				{
+					Name:        "submit",
+					Description: "Submit a bee name suggestion",
+					Run:         bot.Run(submitSuggestion),
+				},
+			},
+		},
+	},
+}
+
+// BeeNameExamples bee name command examples
+var BeeNameExamples = []string{
+	"/beename get",
+	"/beename delete name:Beeatrice",
+	"/beename suggestion submit name:Beeatrice",
+	"/beename suggestion get",
+}
+
+// sendToModeration posts a bee name suggestion to the guild's moderation channel, if one is set
+func sendToModeration(b *bot.Bot, s *discordgo.Session, i *discordgo.InteractionCreate, name string) {
+	if i.GuildID == "" {
+		return
+	}
+	channelID := b.Settings.Get(i.GuildID).ModerationChannelID
+	if channelID == "" {
+		return
+	}
+	locale := bot.GuildLocale(i)
+	_, err := s.ChannelMessageSendComplex(channelID, &discordgo.MessageSend{
+		Embeds:     []*discordgo.MessageEmbed{bot.SimpleEmbed(i18n.T(locale, "beename.suggestion.title"), name, bot.EMBED_YELLOW)},
+		Components: []discordgo.MessageComponent{bot.ComponentActionRow(acceptButton(locale), rejectButton(locale))},
+	})
+	if err != nil {
+		b.ReportError(i, fmt.Errorf("sending suggestion to moderation channel %s: %w", channelID, err))
+	}
+}
+
+// uploadOptions bee name upload subcommand options
+type uploadOptions struct {
+	Name string `option:"name,required" description:"The bee name to upload"`
+}
+
+// deleteOptions bee name delete subcommand options
+type deleteOptions struct {
+	Name string `option:"name,required" description:"The bee name to delete"`
+}
+
+// suggestionOptions bee name suggestion submit subcommand options
+type suggestionOptions struct {
+	Name string `option:"name,required" description:"The bee name suggestion"`
+}
+
+// getName generates a bee name
+func getName(b *bot.Bot, s *discordgo.Session, i *discordgo.InteractionCreate, _ *bot.NoOptions) {
+	ctx, cancel := b.ResponseContext(i)
+	defer cancel()
+	name, err := b.API.GetBeeName(ctx)
+	if err != nil {
+		b.ReportError(i, err)
+		respond(b, s, i, bot.ErrorEmbed(i.Locale, err))
+		return
+	}
+	respond(b, s, i, bot.SimpleEmbed(i18n.T(i.Locale, "beename.name.title"), name.Name, bot.EMBED_GREEN))
+}
+
+// uploadName uploads a bee name, deferring the response since the permission check and upload can take longer than
+// the initial response window
+func uploadName(b *bot.Bot, s *discordgo.Session, i *discordgo.InteractionCreate, opts *uploadOptions) {
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+	})
+	if err != nil {
+		b.ReportError(i, err)
+		return
+	}
+
+	ctx, cancel := b.FollowupContext(i)
+	defer cancel()
+	show := func(embed *discordgo.MessageEmbed) { b.EditResponse(s, i, embed) }
+	if !checkCanManageNames(ctx, b, i, ActionUpload, opts.Name, "beename.upload.no_permission", show) {
+		return
+	}
+	err = b.API.UploadBeeName(ctx, opts.Name)
+	if err != nil {
+		b.ReportError(i, err)
+	}
+	show(bot.ErrorSuccessEmbed(i.Locale, err, i18n.T(i.Locale, "beename.upload.success")))
+	b.Audit.Record(i, ActionUpload, opts.Name, err)
+}
+
+// deleteName deletes a bee name, checking permissions once confirmed since the check can take longer than the
+// initial response window
+func deleteName(b *bot.Bot, s *discordgo.Session, i *discordgo.InteractionCreate, opts *deleteOptions) {
+	confirmation, err := b.Confirm(s, i, bot.ConfirmDialog{
+		Embed: bot.SimpleEmbed(i18n.T(i.Locale, "beename.delete.confirm.title"), i18n.T(i.Locale, "beename.delete.confirm", opts.Name), bot.EMBED_YELLOW),
+	})
+	if err != nil || !confirmation.Confirmed {
+		return
+	}
+
+	ctx, cancel := b.FollowupContext(i)
+	defer cancel()
+	show := func(embed *discordgo.MessageEmbed) { confirmation.Finish(embed) }
+	if !checkCanManageNames(ctx, b, i, ActionDelete, opts.Name, "beename.delete.no_permission", show) {
+		return
+	}
+	err = b.API.DeleteBeeName(ctx, opts.Name)
+	if err != nil {
+		b.ReportError(i, err)
+	}
+	confirmation.Finish(bot.ErrorSuccessEmbed(i.Locale, err, i18n.T(i.Locale, "beename.delete.success")))
+	b.Audit.Record(i, ActionDelete, opts.Name, err)
+}
+
+// getSuggestions shows the first bee name suggestion
+func getSuggestions(b *bot.Bot, s *discordgo.Session, i *discordgo.InteractionCreate, _ *bot.NoOptions) {
+	ctx, cancel := b.ResponseContext(i)
+	defer cancel()
+	suggestions, err := b.API.GetBeeNameSuggestions(ctx)
+	if err != nil {
+		b.ReportError(i, err)
+		respond(b, s, i, bot.ErrorEmbed(i.Locale, err))
+		return
+	}
+
+	embed := bot.SimpleEmbed(i18n.T(i.Locale, "beename.suggestions.title"), i18n.T(i.Locale, "beename.suggestions.empty"), bot.EMBED_GREEN)
+	components := []discordgo.MessageComponent{bot.ComponentActionRow(nextButton(i.Locale))}
+	if len(suggestions.Suggestions) > 0 {
+		embed = bot.SimpleEmbed(i18n.T(i.Locale, "beename.suggestions.title"), suggestions.Suggestions[0], bot.EMBED_GREEN)
+		components = []discordgo.MessageComponent{
+			bot.ComponentActionRow(nextButton(i.Locale), acceptButton(i.Locale), rejectButton(i.Locale)),
 		}
 	}
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Flags:      discordgo.MessageFlagsEphemeral,
+			Embeds:     []*discordgo.MessageEmbed{embed},
+			Components: components,
+		},
+	})
+	if err != nil {
+		b.ReportError(i, err)
+	}
+}
+
+// submitSuggestion submits a bee name suggestion
+func submitSuggestion(b *bot.Bot, s *discordgo.Session, i *discordgo.InteractionCreate, opts *suggestionOptions) {
+	ctx, cancel := b.ResponseContext(i)
+	defer cancel()
+	err := b.API.SubmitBeeNameSuggestion(ctx, opts.Name)
+	if err != nil {
+		b.ReportError(i, err)
+	} else {
+		sendToModeration(b, s, i, opts.Name)
+	}
+	respond(b, s, i, bot.ErrorSuccessEmbed(i.Locale, err, i18n.T(i.Locale, "beename.suggestion.submitted")))
+}
+
+// discordUser fetches the interaction user's NeuralNexus account, creating it if they don't have one yet
+func discordUser(ctx context.Context, b *bot.Bot, i *discordgo.InteractionCreate) (*api.User, error) {
+	u := bot.InteractionUser(i)
+	user, err := b.API.GetUserFromPlatform(ctx, "discord", u.ID)
+	if errors.Is(err, api.ErrNotFound) {
+		return b.API.UpdateUserPlatform(ctx, "discord", u.ID, u)
+	}
+	return user, err
+}
+
+// canManageNames checks whether the interaction user has permission to manage bee names and suggestions
+func canManageNames(ctx context.Context, b *bot.Bot, i *discordgo.InteractionCreate) (bool, error) {
+	user, err := discordUser(ctx, b, i)
+	if err != nil {
+		return false, err
+	}
+	return user.HasPermission(ctx, BeeNamePermission)
+}
+
+// checkCanManageNames checks that the interaction user can manage bee names, once the interaction has been responded to.
+// If they can't, or the check fails, show shows them why; denials are audited as the action on target.
+func checkCanManageNames(ctx context.Context, b *bot.Bot, i *discordgo.InteractionCreate, action, target, noPermissionKey string, show func(embed *discordgo.MessageEmbed)) bool {
+	allowed, err := canManageNames(ctx, b, i)
+	if allowed {
+		return true
+	}
+	denied := err == nil
+	if denied {
+		err = errors.New(i18n.T(i.Locale, noPermissionKey))
+	} else {
+		b.ReportError(i, err)
+	}
+	show(bot.ErrorEmbed(i.Locale, err))
+	if denied {
+		b.Audit.Record(i, action, target, err)
+	}
+	return false
+}
 
+// respond responds to the interaction with the embed
+func respond(b *bot.Bot, s *discordgo.Session, i *discordgo.InteractionCreate, embed *discordgo.MessageEmbed) {
 	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseChannelMessageWithSource,
 		Data: &discordgo.InteractionResponseData{
@@ -293,7 +415,6 @@ func BeeNameCommandHandler(s *discordgo.Session, i *discordgo.InteractionCreate)
 		},
 	})
 	if err != nil {
-		log.Printf("Error handling command interaction: %s", err)
-		return
+		b.ReportError(i, err)
 	}
 }
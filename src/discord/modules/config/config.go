@@ -0,0 +1,388 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/NeuralNexusDev/neuralnexus-discord-bot/src/api"
+	bot "github.com/NeuralNexusDev/neuralnexus-discord-bot/src/discord"
+	"github.com/NeuralNexusDev/neuralnexus-discord-bot/src/i18n"
+	"github.com/bwmarrin/discordgo"
+)
+
+// ConfigCommand guild config command, offering the given modules as choices
+func ConfigCommand(modules []string) *discordgo.ApplicationCommand {
+	var choices []*discordgo.ApplicationCommandOptionChoice
+	for _, module := range modules {
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
+			Name:  module,
+			Value: module,
+		})
+	}
+
+	return &discordgo.ApplicationCommand{
+		Name:                     "config",
+		NameLocalizations:        &map[discordgo.Locale]string{},
+		Description:              "Configure the bot for this server",
+		DescriptionLocalizations: &map[discordgo.Locale]string{},
+		Type:                     discordgo.ChatApplicationCommand,
+		DMPermission:             &bot.DMPermissionFalse,
+		DefaultMemberPermissions: &bot.ManageServerPermission,
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Name:                     "show",
+				NameLocalizations:        map[discordgo.Locale]string{},
+				Description:              "Show this server's settings",
+				DescriptionLocalizations: map[discordgo.Locale]string{},
+				Type:                     discordgo.ApplicationCommandOptionSubCommand,
+			},
+			{
+				Name:                     "module",
+				NameLocalizations:        map[discordgo.Locale]string{},
+				Description:              "Enable or disable a module",
+				DescriptionLocalizations: map[discordgo.Locale]string{},
+				Type:                     discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:                     "module",
+						NameLocalizations:        map[discordgo.Locale]string{},
+						Description:              "The module to configure",
+						DescriptionLocalizations: map[discordgo.Locale]string{},
+						Type:                     discordgo.ApplicationCommandOptionString,
+						Choices:                  choices,
+						Required:                 true,
+					},
+					{
+						Name:                     "enabled",
+						NameLocalizations:        map[discordgo.Locale]string{},
+						Description:              "Whether the module is enabled",
+						DescriptionLocalizations: map[discordgo.Locale]string{},
+						Type:                     discordgo.ApplicationCommandOptionBoolean,
+						Required:                 true,
+					},
+				},
+			},
+			{
+				Name:                     "log-channel",
+				NameLocalizations:        map[discordgo.Locale]string{},
+				Description:              "Set the channel command usage is logged to, leave empty to disable",
+				DescriptionLocalizations: map[discordgo.Locale]string{},
+				Type:                     discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:                     "channel",
+						NameLocalizations:        map[discordgo.Locale]string{},
+						Description:              "The log channel",
+						DescriptionLocalizations: map[discordgo.Locale]string{},
+						Type:                     discordgo.ApplicationCommandOptionChannel,
+						ChannelTypes:             []discordgo.ChannelType{discordgo.ChannelTypeGuildText},
+					},
+				},
+			},
+			{
+				Name:                     "moderation-channel",
+				NameLocalizations:        map[discordgo.Locale]string{},
+				Description:              "Set the channel bee name suggestions are sent to, leave empty to disable",
+				DescriptionLocalizations: map[discordgo.Locale]string{},
+				Type:                     discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:                     "channel",
+						NameLocalizations:        map[discordgo.Locale]string{},
+						Description:              "The moderation channel",
+						DescriptionLocalizations: map[discordgo.Locale]string{},
+						Type:                     discordgo.ApplicationCommandOptionChannel,
+						ChannelTypes:             []discordgo.ChannelType{discordgo.ChannelTypeGuildText},
+					},
+				},
+			},
+			{
+				Name:                     "audit-channel",
+				NameLocalizations:        map[discordgo.Locale]string{},
+				Description:              "Set the channel privileged actions are logged to, leave empty to disable",
+				DescriptionLocalizations: map[discordgo.Locale]string{},
+				Type:                     discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:                     "channel",
+						NameLocalizations:        map[discordgo.Locale]string{},
+						Description:              "The audit channel",
+						DescriptionLocalizations: map[discordgo.Locale]string{},
+						Type:                     discordgo.ApplicationCommandOptionChannel,
+						ChannelTypes:             []discordgo.ChannelType{discordgo.ChannelTypeGuildText},
+					},
+				},
+			},
+			{
+				Name:                     "mcstatus-server",
+				NameLocalizations:        map[discordgo.Locale]string{},
+				Description:              "Set the default Minecraft server, leave empty to clear",
+				DescriptionLocalizations: map[discordgo.Locale]string{},
+				Type:                     discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:                     "host",
+						NameLocalizations:        map[discordgo.Locale]string{},
+						Description:              "The IP address of the server",
+						DescriptionLocalizations: map[discordgo.Locale]string{},
+						Type:                     discordgo.ApplicationCommandOptionString,
+					},
+					{
+						Name:                     "is_bedrock",
+						NameLocalizations:        map[discordgo.Locale]string{},
+						Description:              "Is the server running Bedrock Edition?",
+						DescriptionLocalizations: map[discordgo.Locale]string{},
+						Type:                     discordgo.ApplicationCommandOptionBoolean,
+					},
+				},
+			},
+			{
+				Name:                     "gstatus-server",
+				NameLocalizations:        map[discordgo.Locale]string{},
+				Description:              "Set the default game server, leave empty to clear",
+				DescriptionLocalizations: map[discordgo.Locale]string{},
+				Type:                     discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:                     "game",
+						NameLocalizations:        map[discordgo.Locale]string{},
+						Description:              "Game to check status for",
+						DescriptionLocalizations: map[discordgo.Locale]string{},
+						Type:                     discordgo.ApplicationCommandOptionString,
+					},
+					{
+						Name:                     "host",
+						NameLocalizations:        map[discordgo.Locale]string{},
+						Description:              "The server's IP address or hostname",
+						DescriptionLocalizations: map[discordgo.Locale]string{},
+						Type:                     discordgo.ApplicationCommandOptionString,
+					},
+					{
+						Name:                     "port",
+						NameLocalizations:        map[discordgo.Locale]string{},
+						Description:              "The server's port number",
+						DescriptionLocalizations: map[discordgo.Locale]string{},
+						Type:                     discordgo.ApplicationCommandOptionInteger,
+						MaxValue:                 65535,
+					},
+				},
+			},
+		},
+	}
+}
+
+// ConfigHandler guild config command handler
+func ConfigHandler(b *bot.Bot) bot.InteractionHandler {
+	return func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+		var embed *discordgo.MessageEmbed
+		var change *settingsChange
+		if i.GuildID == "" {
+			embed = bot.ErrorEmbed(i.Locale, errors.New(i18n.T(i.Locale, "config.guild_only")))
+		} else {
+			embed, change = configure(b, s, i)
+		}
+
+		err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Flags:  discordgo.MessageFlagsEphemeral,
+				Embeds: []*discordgo.MessageEmbed{embed},
+			},
+		})
+		if change != nil {
+			b.Audit.Record(i, change.action, change.target, change.err)
+		}
+		if err != nil {
+			b.ReportError(i, err)
+			return
+		}
+	}
+}
+
+// settingsChange settings change to record in the audit log once the interaction is responded to
+type settingsChange struct {
+	action string
+	target string
+	err    error
+}
+
+// moduleOptions config module subcommand options
+type moduleOptions struct {
+	Module  string `option:"module,required"`
+	Enabled bool   `option:"enabled,required"`
+}
+
+// channelOptions config channel subcommand options
+type channelOptions struct {
+	Channel *discordgo.Channel `option:"channel"`
+}
+
+// mcStatusServerOptions config mcstatus-server subcommand options
+type mcStatusServerOptions struct {
+	Host      string `option:"host"`
+	IsBedrock bool   `option:"is_bedrock"`
+}
+
+// gstatusServerOptions config gstatus-server subcommand options
+type gstatusServerOptions struct {
+	Game string `option:"game"`
+	Host string `option:"host"`
+	Port int64  `option:"port"`
+}
+
+// configure applies the config subcommand and returns the response embed, and the change if settings were saved
+func configure(b *bot.Bot, s *discordgo.Session, i *discordgo.InteractionCreate) (*discordgo.MessageEmbed, *settingsChange) {
+	sub, options := bot.CommandOptions(i)
+
+	var success string
+	var update func(gs *bot.GuildSettings)
+	switch sub {
+	case "show":
+		return SettingsEmbed(i.Locale, b.Modules(), b.Settings.Get(i.GuildID)), nil
+	case "module":
+		var opts moduleOptions
+		if err := bot.BindOptions(s, options, &opts); err != nil {
+			return bot.ValidationErrorEmbed(i.Locale, err), nil
+		}
+		update = func(gs *bot.GuildSettings) { gs.SetModuleEnabled(opts.Module, opts.Enabled) }
+		if opts.Enabled {
+			success = i18n.T(i.Locale, "config.module.enabled", opts.Module)
+		} else {
+			success = i18n.T(i.Locale, "config.module.disabled", opts.Module)
+		}
+	case "log-channel", "moderation-channel", "audit-channel":
+		var opts channelOptions
+		if err := bot.BindOptions(s, options, &opts); err != nil {
+			return bot.ValidationErrorEmbed(i.Locale, err), nil
+		}
+		channelID := ""
+		if opts.Channel != nil {
+			channelID = opts.Channel.ID
+		}
+		switch sub {
+		case "log-channel":
+			update = func(gs *bot.GuildSettings) { gs.LogChannelID = channelID }
+			success = channelDescription(i.Locale, "config.log_channel", channelID)
+		case "moderation-channel":
+			update = func(gs *bot.GuildSettings) { gs.ModerationChannelID = channelID }
+			success = channelDescription(i.Locale, "config.moderation_channel", channelID)
+		case "audit-channel":
+			update = func(gs *bot.GuildSettings) { gs.AuditChannelID = channelID }
+			success = channelDescription(i.Locale, "config.audit_channel", channelID)
+		}
+	case "mcstatus-server":
+		var opts mcStatusServerOptions
+		if err := bot.BindOptions(s, options, &opts); err != nil {
+			return bot.ValidationErrorEmbed(i.Locale, err), nil
+		}
+		host := strings.TrimSpace(opts.Host)
+		if host != "" {
+			if err := api.ValidateAddress(host); err != nil {
+				return bot.ErrorEmbed(i.Locale, err), nil
+			}
+		}
+		update = func(gs *bot.GuildSettings) {
+			gs.MCServer = host
+			gs.MCServerBedrock = host != "" && opts.IsBedrock
+		}
+		if host == "" {
+			success = i18n.T(i.Locale, "config.mcstatus_server.cleared")
+		} else {
+			success = i18n.T(i.Locale, "config.mcstatus_server.set", host)
+		}
+	case "gstatus-server":
+		if len(options) == 0 {
+			update = func(gs *bot.GuildSettings) { gs.GameServer = nil }
+			success = i18n.T(i.Locale, "config.gstatus_server.cleared")
+			break
+		}
+		var opts gstatusServerOptions
+		if err := bot.BindOptions(s, options, &opts); err != nil {
+			return bot.ValidationErrorEmbed(i.Locale, err), nil
+		}
+		server := &bot.GameServer{
+			Game: opts.Game,
+			Host: strings.TrimSpace(opts.Host),
+			Port: opts.Port,
+		}
+		if server.Game == "" || server.Host == "" || server.Port == 0 {
+			return bot.ErrorEmbed(i.Locale, errors.New(i18n.T(i.Locale, "config.gstatus_server.incomplete"))), nil
+		}
+		if err := errors.Join(api.ValidateHost(server.Host), api.ValidatePort(server.Port)); err != nil {
+			return bot.ErrorEmbed(i.Locale, err), nil
+		}
+		update = func(gs *bot.GuildSettings) { gs.GameServer = server }
+		success = i18n.T(i.Locale, "config.gstatus_server.set", server.Game, server.Host, server.Port)
+	default:
+		return bot.ErrorEmbed(i.Locale, errors.New(i18n.T(i.Locale, "config.unknown_subcommand"))), nil
+	}
+
+	_, err := b.Settings.Update(i.GuildID, update)
+	change := &settingsChange{action: "config." + sub, target: describeOptions(options), err: err}
+	if err != nil {
+		b.ReportError(i, fmt.Errorf("saving guild settings: %w", err))
+		return bot.ErrorEmbed(i.Locale, errors.New(i18n.T(i.Locale, "config.save_failed"))), change
+	}
+	return bot.ErrorSuccessEmbed(i.Locale, nil, success), change
+}
+
+// SettingsEmbed returns an embed describing the guild's settings
+func SettingsEmbed(locale discordgo.Locale, modules []string, gs *bot.GuildSettings) *discordgo.MessageEmbed {
+	var moduleLines []string
+	for _, module := range modules {
+		if gs.ModuleEnabled(module) {
+			moduleLines = append(moduleLines, "✅ `"+module+"`")
+		} else {
+			moduleLines = append(moduleLines, "❌ `"+module+"`")
+		}
+	}
+
+	notSet := i18n.T(locale, "config.settings.not_set")
+	mcServer := notSet
+	if gs.MCServer != "" {
+		mcServer = "`" + gs.MCServer + "`"
+		if gs.MCServerBedrock {
+			mcServer += " " + i18n.T(locale, "config.settings.bedrock")
+		}
+	}
+	gameServer := notSet
+	if gs.GameServer != nil {
+		gameServer = i18n.T(locale, "config.settings.game_server.value", gs.GameServer.Game, gs.GameServer.Host, gs.GameServer.Port)
+	}
+
+	embed := bot.SimpleEmbed(i18n.T(locale, "config.settings.title"), "", bot.EMBED_GREEN)
+	embed.Fields = []*discordgo.MessageEmbedField{
+		{Name: i18n.T(locale, "config.settings.modules"), Value: strings.Join(moduleLines, "\n")},
+		{Name: i18n.T(locale, "config.settings.log_channel"), Value: channelMention(gs.LogChannelID, notSet), Inline: true},
+		{Name: i18n.T(locale, "config.settings.moderation_channel"), Value: channelMention(gs.ModerationChannelID, notSet), Inline: true},
+		{Name: i18n.T(locale, "config.settings.audit_channel"), Value: channelMention(gs.AuditChannelID, notSet), Inline: true},
+		{Name: i18n.T(locale, "config.settings.mc_server"), Value: mcServer},
+		{Name: i18n.T(locale, "config.settings.game_server"), Value: gameServer},
+	}
+	return embed
+}
+
+func channelMention(channelID, notSet string) string {
+	if channelID == "" {
+		return notSet
+	}
+	return "<#" + channelID + ">"
+}
+
+// channelDescription describes a channel setting change using the "<key>.set" and "<key>.cleared" messages
+func channelDescription(locale discordgo.Locale, key, channelID string) string {
+	if channelID == "" {
+		return i18n.T(locale, key+".cleared")
+	}
+	return i18n.T(locale, key+".set", channelID)
+}
+
+// describeOptions describes the subcommand's options for the audit log, like "module:mcstatus enabled:false"
+func describeOptions(options []*discordgo.ApplicationCommandInteractionDataOption) string {
+	var parts []string
+	for _, o := range options {
+		parts = append(parts, fmt.Sprintf("%s:%v", o.Name, o.Value))
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,151 @@
+package diagnostics
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NeuralNexusDev/neuralnexus-discord-bot/src/api"
+	bot "github.com/NeuralNexusDev/neuralnexus-discord-bot/src/discord"
+	"github.com/NeuralNexusDev/neuralnexus-discord-bot/src/i18n"
+	"github.com/NeuralNexusDev/neuralnexus-discord-bot/src/metrics"
+	"github.com/bwmarrin/discordgo"
+)
+
+var administratorPermission int64 = discordgo.PermissionAdministrator
+
+// BotCommand bot owner diagnostics command
+var BotCommand = &discordgo.ApplicationCommand{
+	Name:                     "bot",
+	NameLocalizations:        &map[discordgo.Locale]string{},
+	Description:              "Bot owner diagnostics",
+	DescriptionLocalizations: &map[discordgo.Locale]string{},
+	Type:                     discordgo.ChatApplicationCommand,
+	DMPermission:             &bot.DMPermissionTrue,
+	DefaultMemberPermissions: &administratorPermission,
+	Options: []*discordgo.ApplicationCommandOption{
+		{
+			Name:                     "status",
+			NameLocalizations:        map[discordgo.Locale]string{},
+			Description:              "Show the bot's health",
+			DescriptionLocalizations: map[discordgo.Locale]string{},
+			Type:                     discordgo.ApplicationCommandOptionSubCommand,
+		},
+		{
+			Name:                     "reload-commands",
+			NameLocalizations:        map[discordgo.Locale]string{},
+			Description:              "Re-register the bot's commands with Discord",
+			DescriptionLocalizations: map[discordgo.Locale]string{},
+			Type:                     discordgo.ApplicationCommandOptionSubCommand,
+		},
+	},
+}
+
+// BotHandler bot owner diagnostics command handler
+func BotHandler(b *bot.Bot) bot.InteractionHandler {
+	return func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+		var embed *discordgo.MessageEmbed
+		if !b.IsOwner(bot.InteractionUser(i).ID) {
+			embed = bot.ErrorEmbed(i.Locale, errors.New(i18n.T(i.Locale, "diagnostics.owner_only")))
+		} else {
+			switch i.ApplicationCommandData().Options[0].Name {
+			case "status":
+				embed = StatusEmbed(b, s, i.Locale)
+			case "reload-commands":
+				err := b.RegisterCommands()
+				if err != nil {
+					b.ReportError(i, err)
+				}
+				embed = bot.ErrorSuccessEmbed(i.Locale, err, i18n.T(i.Locale, "diagnostics.reloaded", len(b.Commands())))
+			}
+		}
+
+		err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Flags:  discordgo.MessageFlagsEphemeral,
+				Embeds: []*discordgo.MessageEmbed{embed},
+			},
+		})
+		if err != nil {
+			b.ReportError(i, err)
+			return
+		}
+	}
+}
+
+// StatusEmbed returns an embed describing the bot's health
+func StatusEmbed(b *bot.Bot, s *discordgo.Session, locale discordgo.Locale) *discordgo.MessageEmbed {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	var caches []string
+	for _, c := range metrics.Caches() {
+		caches = append(caches, fmt.Sprintf("`%s` %.1f%% (%d/%d)", c.Name, c.HitRate()*100, c.Hits.Value(), c.Hits.Value()+c.Misses.Value()))
+	}
+	if len(caches) == 0 {
+		caches = append(caches, i18n.T(locale, "diagnostics.none"))
+	}
+
+	apiLatency := i18n.T(locale, "diagnostics.none")
+	if stats := api.RequestLatency.Stats(); stats.Count > 0 {
+		apiLatency = i18n.T(locale, "diagnostics.latency", stats.Last.Round(time.Millisecond), stats.Average.Round(time.Millisecond), stats.Count)
+	}
+
+	color := bot.EMBED_GREEN
+	breaker := b.API.Breaker.Stats()
+	apiStatus := i18n.T(locale, "diagnostics.api.available")
+	switch b.API.Breaker.State() {
+	case api.BreakerOpen:
+		apiStatus = i18n.T(locale, "diagnostics.api.unavailable", breaker.Failures, breaker.RetryAt.Unix())
+		color = bot.EMBED_YELLOW
+	case api.BreakerHalfOpen:
+		apiStatus = i18n.T(locale, "diagnostics.api.recovering")
+		color = bot.EMBED_YELLOW
+	}
+
+	embed := bot.SimpleEmbed(i18n.T(locale, "diagnostics.title"), "", color)
+	embed.Fields = []*discordgo.MessageEmbedField{
+		{Name: i18n.T(locale, "diagnostics.uptime"), Value: time.Since(b.StartedAt).Round(time.Second).String(), Inline: true},
+		{Name: i18n.T(locale, "diagnostics.version"), Value: Version(), Inline: true},
+		{Name: i18n.T(locale, "diagnostics.gateway_latency"), Value: s.HeartbeatLatency().Round(time.Millisecond).String(), Inline: true},
+		{Name: i18n.T(locale, "diagnostics.guilds"), Value: strconv.Itoa(len(s.State.Guilds)), Inline: true},
+		{Name: i18n.T(locale, "diagnostics.goroutines"), Value: strconv.Itoa(runtime.NumGoroutine()), Inline: true},
+		{Name: i18n.T(locale, "diagnostics.memory"), Value: i18n.T(locale, "diagnostics.memory.value", mem.HeapAlloc>>20, mem.Sys>>20), Inline: true},
+		{Name: i18n.T(locale, "diagnostics.caches"), Value: strings.Join(caches, "\n")},
+		{Name: i18n.T(locale, "diagnostics.api_status"), Value: apiStatus},
+		{Name: i18n.T(locale, "diagnostics.api_latency"), Value: apiLatency},
+		{Name: i18n.T(locale, "diagnostics.api_retries"), Value: i18n.T(locale, "diagnostics.retries", api.RequestRetries.Value(), api.RetriesExhausted.Value())},
+	}
+	return embed
+}
+
+// Version returns the module version and VCS commit the bot was built from
+func Version() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "unknown"
+	}
+	version := info.Main.Version
+	revision, modified := "", false
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			revision = setting.Value
+		case "vcs.modified":
+			modified = setting.Value == "true"
+		}
+	}
+	if revision != "" {
+		version += " (" + revision[:min(12, len(revision))]
+		if modified {
+			version += "-dirty"
+		}
+		version += ")"
+	}
+	return version
+}
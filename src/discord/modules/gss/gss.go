@@ -1,91 +1,136 @@
 package gss
 
 import (
+	"context"
+	"errors"
 	"log"
 	"strconv"
+	"strings"
 
 	"github.com/NeuralNexusDev/neuralnexus-discord-bot/src/api"
 	bot "github.com/NeuralNexusDev/neuralnexus-discord-bot/src/discord"
+	"github.com/NeuralNexusDev/neuralnexus-discord-bot/src/i18n"
 	"github.com/bwmarrin/discordgo"
 )
 
+// refreshComponentID custom ID of the refresh button, as "gstatus_refresh:<game>:<port>:<host>"
+const refreshComponentID = "gstatus_refresh"
+
 // GSSCommand game server status command
-var GSSCommand = &discordgo.ApplicationCommand{
-	Name:                     "gstatus",
-	NameLocalizations:        &map[discordgo.Locale]string{},
-	Description:              "Check a game server's status",
-	DescriptionLocalizations: &map[discordgo.Locale]string{},
-	Type:                     discordgo.ChatApplicationCommand,
-	DMPermission:             &bot.DMPermissionTrue,
-	Options: []*discordgo.ApplicationCommandOption{
-		{
-			Name:                     "game",
-			NameLocalizations:        map[discordgo.Locale]string{},
-			Description:              "Game to check status for",
-			DescriptionLocalizations: map[discordgo.Locale]string{},
-			Type:                     discordgo.ApplicationCommandOptionString,
-			Required:                 true,
-		},
-		{
-			Name:                     "host",
-			NameLocalizations:        map[discordgo.Locale]string{},
-			Description:              "The server's IP address or hostname",
-			DescriptionLocalizations: map[discordgo.Locale]string{},
-			Type:                     discordgo.ApplicationCommandOptionString,
-			Required:                 true,
-		},
-		{
-			Name:                     "port",
-			NameLocalizations:        map[discordgo.Locale]string{},
-			Description:              "The server's port number",
-			DescriptionLocalizations: map[discordgo.Locale]string{},
-			Type:                     discordgo.ApplicationCommandOptionInteger,
-			MaxValue:                 65535,
-			Required:                 true,
-		},
-	},
+var GSSCommand = &bot.CommandSpec{
+	Name:         "gstatus",
+	Description:  "Check a game server's status",
+	DMPermission: true,
+	Run:          bot.Run(gameServerStatus),
+}
+
+// GSSExamples game server status command examples
+var GSSExamples = []string{
+	"/gstatus game:minecraft host:mc.hypixel.net port:25565",
+	"/gstatus game:valheim host:203.0.113.7 port:2457",
+}
+
+// gssOptions game server status command options
+type gssOptions struct {
+	Game string `option:"game" description:"Game to check status for"`
+	Host string `option:"host" description:"The server's IP address or hostname"`
+	Port int64  `option:"port" description:"The server's port number" max:"65535"`
+}
+
+func gameServerStatus(b *bot.Bot, s *discordgo.Session, i *discordgo.InteractionCreate, opts *gssOptions) {
+	ctx, cancel := b.ResponseContext(i)
+	defer cancel()
+	game, host, port := opts.Game, opts.Host, opts.Port
+	if i.GuildID != "" {
+		if server := b.Settings.Get(i.GuildID).GameServer; server != nil && game == "" && host == "" && port == 0 {
+			game, host, port = server.Game, server.Host, server.Port
+		}
+	}
+	if game == "" || host == "" || port == 0 {
+		err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseChannelMessageWithSource,
+			Data: &discordgo.InteractionResponseData{
+				Flags:  discordgo.MessageFlagsEphemeral,
+				Embeds: []*discordgo.MessageEmbed{bot.ErrorEmbed(i.Locale, errors.New(i18n.T(i.Locale, "gstatus.missing_server")))},
+			},
+		})
+		if err != nil {
+			b.ReportError(i, err)
+		}
+		return
+	}
+
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: statusResponse(ctx, b, i, game, host, port),
+	})
+	if err != nil {
+		b.ReportError(i, err)
+	}
 }
 
-// GSSHandler game server status command handler
-func GSSHandler(s *discordgo.Session, i *discordgo.InteractionCreate) {
-	options := i.ApplicationCommandData().Options
-	game := options[0].StringValue()
-	host := options[1].StringValue()
-	port := options[2].IntValue()
+// GSSComponentHandlers game server status component handlers
+func GSSComponentHandlers(b *bot.Bot) map[string]bot.InteractionHandler {
+	return map[string]bot.InteractionHandler{
+		refreshComponentID: func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+			customID := i.MessageComponentData().CustomID
+			parts := strings.SplitN(strings.TrimPrefix(customID, refreshComponentID+":"), ":", 3)
+			if len(parts) != 3 {
+				b.ReportError(i, errors.New("invalid gstatus refresh custom ID "+customID))
+				return
+			}
+			port, err := strconv.ParseInt(parts[1], 10, 64)
+			if err != nil {
+				b.ReportError(i, err)
+				return
+			}
+			ctx, cancel := b.ResponseContext(i)
+			defer cancel()
+
+			err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+				Type: discordgo.InteractionResponseUpdateMessage,
+				Data: statusResponse(api.NoCache(ctx), b, i, parts[0], parts[2], port),
+			})
+			if err != nil {
+				b.ReportError(i, err)
+			}
+		},
+	}
+}
 
+// statusResponse fetches the server's status and renders it, along with a refresh button
+func statusResponse(ctx context.Context, b *bot.Bot, i *discordgo.InteractionCreate, game, host string, port int64) *discordgo.InteractionResponseData {
 	title := ""
 	description := ""
 	color := bot.EMBED_GREEN
+	var footer *discordgo.MessageEmbedFooter
 
-	status, err := api.GetServerStatus(game, host, port)
-	if err != nil {
+	status, err := b.API.GetServerStatus(ctx, game, host, port)
+	if errors.Is(err, api.ErrCircuitOpen) || errors.Is(err, api.ErrInvalidInput) {
+		embed := bot.ErrorEmbed(i.Locale, err)
+		title, description, color = embed.Title, embed.Description, embed.Color
+	} else if err != nil {
 		log.Printf("Error fetching server status: %v", err)
-		title = "Error:"
-		description = "Whoops, something went wrong,\n"
-		description += "couldn't reach " + host + ":" + strconv.FormatInt(port, 10) + ".\t¯\\\\_(\"/)\\_/¯" + "\n"
-		description += err.Error()
+		title = i18n.T(i.Locale, "gstatus.error.title")
+		description = i18n.T(i.Locale, "gstatus.error.description", host+":"+strconv.FormatInt(port, 10), bot.ErrorMessage(i.Locale, err))
 		color = bot.EMBED_RED
 	} else {
 		title = status.Host + ":" + strconv.Itoa(status.Port)
-		description += "Name: " + status.Name + "\n"
-		description += "Map: " + status.MapName + "\n"
-		description += "Players: " + strconv.Itoa(status.NumPlayers) + "/" + strconv.Itoa(status.MaxPlayers)
+		description = i18n.T(i.Locale, "gstatus.status.description", status.Name, status.MapName, status.NumPlayers, status.MaxPlayers)
+		if age := bot.CacheAge(i.Locale, status.FetchedAt); age != "" {
+			footer = &discordgo.MessageEmbedFooter{Text: age}
+		}
 	}
 
-	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-		Type: discordgo.InteractionResponseChannelMessageWithSource,
-		Data: &discordgo.InteractionResponseData{
-			Embeds: []*discordgo.MessageEmbed{
-				{
-					Title:       title,
-					Description: description,
-					Color:       color,
-				},
+	return &discordgo.InteractionResponseData{
+		Embeds: []*discordgo.MessageEmbed{
+			{
+				Title:       title,
+				Description: description,
+				Color:       color,
+				Footer:      footer,
 			},
 		},
-	})
-	if err != nil {
-		log.Printf("Failed to respond to interaction: %v", err)
-		return
+		Components: bot.RefreshComponents(i.Locale, refreshComponentID+":"+game+":"+strconv.FormatInt(port, 10)+":"+host),
 	}
 }
@@ -0,0 +1,261 @@
+package help
+
+import (
+	"errors"
+	"strings"
+
+	bot "github.com/NeuralNexusDev/neuralnexus-discord-bot/src/discord"
+	"github.com/NeuralNexusDev/neuralnexus-discord-bot/src/i18n"
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	// commandsPerPage commands listed on each overview page, and offered by its select menu
+	commandsPerPage = 4
+	// maxCommandChoices choices an option can have, with more commands the option takes any name
+	maxCommandChoices = 25
+)
+
+// HelpCommand help command, offering the given commands as choices
+func HelpCommand(commands []*discordgo.ApplicationCommand) *discordgo.ApplicationCommand {
+	var choices []*discordgo.ApplicationCommandOptionChoice
+	for _, cmd := range commands {
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
+			Name:  cmd.Name,
+			Value: cmd.Name,
+		})
+	}
+	choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
+		Name:  "help",
+		Value: "help",
+	})
+	if len(choices) > maxCommandChoices {
+		choices = nil
+	}
+
+	return &discordgo.ApplicationCommand{
+		Name:                     "help",
+		NameLocalizations:        &map[discordgo.Locale]string{},
+		Description:              "List the bot's commands",
+		DescriptionLocalizations: &map[discordgo.Locale]string{},
+		Type:                     discordgo.ChatApplicationCommand,
+		DMPermission:             &bot.DMPermissionTrue,
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Name:                     "command",
+				NameLocalizations:        map[discordgo.Locale]string{},
+				Description:              "Show the details of a command",
+				DescriptionLocalizations: map[discordgo.Locale]string{},
+				Type:                     discordgo.ApplicationCommandOptionString,
+				Choices:                  choices,
+			},
+		},
+	}
+}
+
+// HelpPaginator help overview and command pages, the paginator's arg is the command shown
+var HelpPaginator = &bot.Paginator{
+	ID:     "help_page",
+	Render: renderHelp,
+}
+
+// HelpHandler help command handler
+func HelpHandler(b *bot.Bot) bot.InteractionHandler {
+	return func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+		command := ""
+		if options := i.ApplicationCommandData().Options; len(options) > 0 {
+			command = options[0].StringValue()
+		}
+		HelpPaginator.Respond(b, s, i, command, discordgo.MessageFlagsEphemeral)
+	}
+}
+
+func renderHelp(b *bot.Bot, i *discordgo.InteractionCreate, state bot.PageState) *bot.Page {
+	commands := visibleCommands(b, i)
+	if state.Arg != "" {
+		return commandPage(b, i.Locale, commands, state)
+	}
+	return overviewPage(i.Locale, commands, state)
+}
+
+// visibleCommands returns the commands the interaction user can use
+func visibleCommands(b *bot.Bot, i *discordgo.InteractionCreate) []*discordgo.ApplicationCommand {
+	var commands []*discordgo.ApplicationCommand
+	for _, cmd := range b.Commands() {
+		if b.CanUseCommand(i, cmd) {
+			commands = append(commands, cmd)
+		}
+	}
+	return commands
+}
+
+// overviewPage lists a page of commands, with a menu to open the page's commands
+func overviewPage(locale discordgo.Locale, commands []*discordgo.ApplicationCommand, state bot.PageState) *bot.Page {
+	pages := (len(commands) + commandsPerPage - 1) / commandsPerPage
+	page := max(0, min(state.Page, pages-1))
+	commands = commands[page*commandsPerPage : min((page+1)*commandsPerPage, len(commands))]
+
+	embed := bot.SimpleEmbed(i18n.T(locale, "help.title"), i18n.T(locale, "help.description"), bot.EMBED_GREEN)
+	for _, cmd := range commands {
+		var lines []string
+		lines = append(lines, commandDescription(locale, cmd))
+		for _, u := range usages(locale, "/"+cmd.Name, cmd.Options) {
+			if u.path != "/"+cmd.Name {
+				lines = append(lines, "`"+u.path+"` - "+u.description)
+			}
+		}
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:  "/" + cmd.Name,
+			Value: strings.Join(lines, "\n"),
+		})
+	}
+
+	var menuOptions []discordgo.SelectMenuOption
+	for _, cmd := range commands {
+		menuOptions = append(menuOptions, discordgo.SelectMenuOption{
+			Label:       "/" + cmd.Name,
+			Value:       cmd.Name,
+			Description: truncate(commandDescription(locale, cmd), 100),
+		})
+	}
+
+	return &bot.Page{
+		Embed: embed,
+		Pages: pages,
+		Components: []discordgo.MessageComponent{
+			bot.ComponentActionRow(discordgo.SelectMenu{
+				CustomID:    state.SelectID(),
+				Placeholder: i18n.T(locale, "help.select"),
+				Options:     menuOptions,
+			}),
+		},
+	}
+}
+
+// commandPage details a command's subcommands, options and examples
+func commandPage(b *bot.Bot, locale discordgo.Locale, commands []*discordgo.ApplicationCommand, state bot.PageState) *bot.Page {
+	name := state.Arg
+	back := []discordgo.MessageComponent{
+		bot.ComponentActionRow(discordgo.Button{
+			Label:    i18n.T(locale, "help.back"),
+			Style:    discordgo.SecondaryButton,
+			CustomID: state.LinkID(0, ""),
+		}),
+	}
+
+	var cmd *discordgo.ApplicationCommand
+	for _, c := range commands {
+		if c.Name == name {
+			cmd = c
+		}
+	}
+	if cmd == nil {
+		return &bot.Page{
+			Embed:      bot.ErrorEmbed(locale, errors.New(i18n.T(locale, "help.unknown_command", name))),
+			Components: back,
+		}
+	}
+
+	embed := bot.SimpleEmbed("/"+cmd.Name, commandDescription(locale, cmd), bot.EMBED_GREEN)
+	for _, u := range usages(locale, "/"+cmd.Name, cmd.Options) {
+		var lines []string
+		if u.path != "/"+cmd.Name {
+			lines = append(lines, u.description)
+		}
+		for _, o := range u.options {
+			required := i18n.T(locale, "help.optional")
+			if o.Required {
+				required = i18n.T(locale, "help.required")
+			}
+			lines = append(lines, "`"+o.Name+"` ("+required+") - "+localized(locale, o.Description, o.DescriptionLocalizations))
+		}
+		if len(lines) == 0 {
+			lines = append(lines, i18n.T(locale, "help.no_options"))
+		}
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:  u.signature(),
+			Value: strings.Join(lines, "\n"),
+		})
+	}
+	if examples := b.CommandExamples(cmd.Name); len(examples) > 0 {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:  i18n.T(locale, "help.examples"),
+			Value: "`" + strings.Join(examples, "`\n`") + "`",
+		})
+	}
+	if len(embed.Fields) > 25 {
+		embed.Fields = embed.Fields[:25]
+	}
+
+	return &bot.Page{
+		Embed:      embed,
+		Components: back,
+	}
+}
+
+// usage invocable command path, e.g. "/beename suggestion submit", and its options
+type usage struct {
+	path        string
+	description string
+	options     []*discordgo.ApplicationCommandOption
+}
+
+// signature returns the usage with its options, e.g. "/mcstatus <host> [is_bedrock]"
+func (u usage) signature() string {
+	sig := u.path
+	for _, o := range u.options {
+		if o.Required {
+			sig += " <" + o.Name + ">"
+		} else {
+			sig += " [" + o.Name + "]"
+		}
+	}
+	return sig
+}
+
+// usages flattens subcommand groups and subcommands into their invocable paths
+func usages(locale discordgo.Locale, path string, options []*discordgo.ApplicationCommandOption) []usage {
+	var subs []usage
+	var params []*discordgo.ApplicationCommandOption
+	for _, o := range options {
+		switch o.Type {
+		case discordgo.ApplicationCommandOptionSubCommandGroup:
+			subs = append(subs, usages(locale, path+" "+o.Name, o.Options)...)
+		case discordgo.ApplicationCommandOptionSubCommand:
+			subs = append(subs, usage{
+				path:        path + " " + o.Name,
+				description: localized(locale, o.Description, o.DescriptionLocalizations),
+				options:     o.Options,
+			})
+		default:
+			params = append(params, o)
+		}
+	}
+	if len(subs) > 0 {
+		return subs
+	}
+	return []usage{{path: path, options: params}}
+}
+
+// commandDescription returns the command's description in the locale
+func commandDescription(locale discordgo.Locale, cmd *discordgo.ApplicationCommand) string {
+	if cmd.DescriptionLocalizations == nil {
+		return cmd.Description
+	}
+	return localized(locale, cmd.Description, *cmd.DescriptionLocalizations)
+}
+
+// localized returns the localization for the locale, or the default text
+func localized(locale discordgo.Locale, text string, localizations map[discordgo.Locale]string) string {
+	if l, ok := localizations[locale]; ok {
+		return l
+	}
+	return text
+}
+
+func truncate(s string, n int) string {
+	if r := []rune(s); len(r) > n {
+		return string(r[:n-1]) + "…"
+	}
+	return s
+}
@@ -1,116 +1,166 @@
 package mcstatus
 
 import (
-	"log"
-	"strconv"
+	"context"
+	"errors"
+	"net/url"
 	"strings"
 
 	"github.com/NeuralNexusDev/neuralnexus-discord-bot/src/api"
 	bot "github.com/NeuralNexusDev/neuralnexus-discord-bot/src/discord"
+	"github.com/NeuralNexusDev/neuralnexus-discord-bot/src/i18n"
 	"github.com/bwmarrin/discordgo"
 )
 
+// refreshComponentID custom ID of the refresh button, as "mcstatus_refresh:<edition>:<host>"
+const refreshComponentID = "mcstatus_refresh"
+
+// Server editions in refresh button custom IDs
+const (
+	editionJava    = "j"
+	editionBedrock = "b"
+)
+
 // MCStatusCommand minecraft server status command
-var MCStatusCommand = &discordgo.ApplicationCommand{
-	Name:                     "mcstatus",
-	NameLocalizations:        &map[discordgo.Locale]string{},
-	Description:              "Check a Minecraft server's status",
-	DescriptionLocalizations: &map[discordgo.Locale]string{},
-	Type:                     discordgo.ChatApplicationCommand,
-	DMPermission:             &bot.DMPermissionTrue,
-	Options: []*discordgo.ApplicationCommandOption{
-		{
-			Name:        "host",
-			Description: "The IP address of the server",
-			Type:        discordgo.ApplicationCommandOptionString,
-			Required:    true,
-		},
-		{
-			Name:        "is_bedrock",
-			Description: "Is the server running Bedrock Edition?",
-			Type:        discordgo.ApplicationCommandOptionBoolean,
-			Required:    false,
-		},
-	},
+var MCStatusCommand = &bot.CommandSpec{
+	Name:         "mcstatus",
+	Description:  "Check a Minecraft server's status",
+	DMPermission: true,
+	Run:          bot.Run(mcStatus),
 }
 
-// MCStatusHandler minecraft server status handler
-func MCStatusHandler(s *discordgo.Session, i *discordgo.InteractionCreate) {
-	options := i.ApplicationCommandData().Options
-	host := options[0].StringValue()
-	isBedrock := false
-	if len(options) > 1 {
-		isBedrock = options[1].BoolValue()
-	}
+// MCStatusExamples minecraft server status command examples
+var MCStatusExamples = []string{
+	"/mcstatus host:mc.hypixel.net",
+	"/mcstatus host:play.example.com:25566",
+	"/mcstatus host:geo.hivebedrock.network is_bedrock:True",
+}
 
-	var status *api.MCServerStatus
-	var err error
-	if isBedrock {
-		status, err = api.GetMCServerStatus(host + "?bedrock=true")
-	} else {
-		status, err = api.GetMCServerStatus(host)
+// mcStatusOptions minecraft server status command options
+type mcStatusOptions struct {
+	Host      string `option:"host" description:"The IP address of the server"`
+	IsBedrock bool   `option:"is_bedrock" description:"Is the server running Bedrock Edition?"`
+}
+
+func mcStatus(b *bot.Bot, s *discordgo.Session, i *discordgo.InteractionCreate, opts *mcStatusOptions) {
+	ctx, cancel := b.ResponseContext(i)
+	defer cancel()
+	host, isBedrock := opts.Host, opts.IsBedrock
+	if host == "" && i.GuildID != "" {
+		gs := b.Settings.Get(i.GuildID)
+		host = gs.MCServer
+		isBedrock = isBedrock || gs.MCServerBedrock
 	}
-	if err != nil {
-		description := "Whoops, something went wrong,\n"
-		description += "couldn't reach " + host + ".\t¯\\\\_(\"/)\\_/¯" + "\n"
-		description += err.Error()
+	if host == "" {
 		err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 			Type: discordgo.InteractionResponseChannelMessageWithSource,
 			Data: &discordgo.InteractionResponseData{
-				Embeds: []*discordgo.MessageEmbed{
-					{
-						Title:       "Error fetching server status",
-						Description: description,
-						Color:       bot.EMBED_RED,
-					},
-				},
+				Flags:  discordgo.MessageFlagsEphemeral,
+				Embeds: []*discordgo.MessageEmbed{bot.ErrorEmbed(i.Locale, errors.New(i18n.T(i.Locale, "mcstatus.missing_host")))},
 			},
 		})
 		if err != nil {
-			log.Printf("Failed to respond to interaction: %v", err)
-			return
+			b.ReportError(i, err)
 		}
 		return
 	}
 
-	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseChannelMessageWithSource,
-		Data: &discordgo.InteractionResponseData{
+		Data: statusResponse(ctx, b, i, host, isBedrock),
+	})
+	if err != nil {
+		b.ReportError(i, err)
+	}
+}
+
+// MCStatusComponentHandlers minecraft server status component handlers
+func MCStatusComponentHandlers(b *bot.Bot) map[string]bot.InteractionHandler {
+	return map[string]bot.InteractionHandler{
+		refreshComponentID: func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+			edition, host, ok := strings.Cut(strings.TrimPrefix(i.MessageComponentData().CustomID, refreshComponentID+":"), ":")
+			if !ok {
+				b.ReportError(i, errors.New("invalid mcstatus refresh custom ID "+i.MessageComponentData().CustomID))
+				return
+			}
+			ctx, cancel := b.ResponseContext(i)
+			defer cancel()
+
+			err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+				Type: discordgo.InteractionResponseUpdateMessage,
+				Data: statusResponse(api.NoCache(ctx), b, i, host, edition == editionBedrock),
+			})
+			if err != nil {
+				b.ReportError(i, err)
+			}
+		},
+	}
+}
+
+// statusResponse fetches the server's status and renders it, along with a refresh button
+func statusResponse(ctx context.Context, b *bot.Bot, i *discordgo.InteractionCreate, host string, isBedrock bool) *discordgo.InteractionResponseData {
+	edition := editionJava
+	if isBedrock {
+		edition = editionBedrock
+	}
+	components := bot.RefreshComponents(i.Locale, refreshComponentID+":"+edition+":"+host)
+
+	status, err := b.API.GetMCServerStatus(ctx, host, isBedrock)
+	if errors.Is(err, api.ErrCircuitOpen) || errors.Is(err, api.ErrInvalidInput) {
+		return &discordgo.InteractionResponseData{
+			Embeds:     []*discordgo.MessageEmbed{bot.ErrorEmbed(i.Locale, err)},
+			Components: components,
+		}
+	}
+	if err != nil {
+		return &discordgo.InteractionResponseData{
 			Embeds: []*discordgo.MessageEmbed{
 				{
-					URL:         "https://neuralnexus.dev/mcstatus/" + host,
-					Title:       status.Host,
-					Description: strings.ReplaceAll(status.Motd, "\\n", "\n"),
-					Color:       bot.EMBED_GREEN,
-					Thumbnail: &discordgo.MessageEmbedThumbnail{
-						URL: "https://api.neuralnexus.dev/api/v1/mcstatus/icon/" + host,
+					Title:       i18n.T(i.Locale, "mcstatus.error.title"),
+					Description: i18n.T(i.Locale, "mcstatus.error.description", host, bot.ErrorMessage(i.Locale, err)),
+					Color:       bot.EMBED_RED,
+				},
+			},
+			Components: components,
+		}
+	}
+
+	footer := i18n.T(i.Locale, "mcstatus.footer")
+	if age := bot.CacheAge(i.Locale, status.FetchedAt); age != "" {
+		footer += " • " + age
+	}
+	return &discordgo.InteractionResponseData{
+		Embeds: []*discordgo.MessageEmbed{
+			{
+				URL:         "https://neuralnexus.dev/mcstatus/" + url.PathEscape(host),
+				Title:       status.Host,
+				Description: strings.ReplaceAll(status.Motd, "\\n", "\n"),
+				Color:       bot.EMBED_GREEN,
+				Thumbnail: &discordgo.MessageEmbedThumbnail{
+					URL: b.API.MCServerIconURL(host),
+				},
+				Footer: &discordgo.MessageEmbedFooter{
+					Text: footer,
+				},
+				Fields: []*discordgo.MessageEmbedField{
+					{
+						Name:   i18n.T(i.Locale, "mcstatus.field.players"),
+						Value:  i18n.T(i.Locale, "mcstatus.field.players.value", status.NumPlayers, status.MaxPlayers),
+						Inline: true,
 					},
-					Footer: &discordgo.MessageEmbedFooter{
-						Text: "Powered by NeuralNexus.dev",
+					{
+						Name:   i18n.T(i.Locale, "mcstatus.field.version"),
+						Value:  status.Version,
+						Inline: true,
 					},
-					Fields: []*discordgo.MessageEmbedField{
-						{
-							Name:   "Players",
-							Value:  "Online: " + strconv.Itoa(status.NumPlayers) + "/" + strconv.Itoa(status.MaxPlayers),
-							Inline: true,
-						},
-						{
-							Name:   "Version",
-							Value:  status.Version,
-							Inline: true,
-						},
-						{
-							Name:   "Map",
-							Value:  status.Map,
-							Inline: true,
-						},
+					{
+						Name:   i18n.T(i.Locale, "mcstatus.field.map"),
+						Value:  status.Map,
+						Inline: true,
 					},
 				},
 			},
 		},
-	})
-	if err != nil {
-		log.Printf("Failed to respond to interaction: %v", err)
-		return
+		Components: components,
 	}
 }
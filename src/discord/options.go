@@ -0,0 +1,241 @@
+package discord
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/NeuralNexusDev/neuralnexus-discord-bot/src/i18n"
+	"github.com/bwmarrin/discordgo"
+)
+
+// OptionError problem binding a single option
+type OptionError struct {
+	Option string
+	// Key message catalog key describing the problem
+	Key string
+}
+
+// OptionErrors problems binding an interaction's options
+type OptionErrors []OptionError
+
+func (e OptionErrors) Error() string {
+	var problems []string
+	for _, oe := range e {
+		problems = append(problems, oe.Option+": "+i18n.T(i18n.DefaultLocale, oe.Key))
+	}
+	return "invalid options: " + strings.Join(problems, ", ")
+}
+
+// CommandOptions returns the invoked subcommand path, like "suggestion submit", and that subcommand's options
+func CommandOptions(i *discordgo.InteractionCreate) (string, []*discordgo.ApplicationCommandInteractionDataOption) {
+	var path []string
+	options := i.ApplicationCommandData().Options
+	for len(options) == 1 && (options[0].Type == discordgo.ApplicationCommandOptionSubCommand || options[0].Type == discordgo.ApplicationCommandOptionSubCommandGroup) {
+		path = append(path, options[0].Name)
+		options = options[0].Options
+	}
+	return strings.Join(path, " "), options
+}
+
+// BindOptions binds options by name into dst, a pointer to a struct whose fields are tagged with
+// `option:"name"` or `option:"name,required"` and optionally `default:"value"`.
+// Other option flags, like autocomplete, are only used by CommandSpec.
+// Fields may be strings, integers, floats, bools, or *discordgo.User, *discordgo.Channel and *discordgo.Role.
+// Missing required options and options of the wrong type are returned as OptionErrors.
+func BindOptions(s *discordgo.Session, options []*discordgo.ApplicationCommandInteractionDataOption, dst any) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		return errors.New("BindOptions: dst must be a pointer to a struct")
+	}
+	v = v.Elem()
+
+	byName := map[string]*discordgo.ApplicationCommandInteractionDataOption{}
+	for _, o := range options {
+		byName[o.Name] = o
+	}
+
+	var errs OptionErrors
+	t := v.Type()
+	for idx := 0; idx < t.NumField(); idx++ {
+		field := t.Field(idx)
+		tag, ok := field.Tag.Lookup("option")
+		if !ok || !field.IsExported() {
+			continue
+		}
+		name, flags := parseOptionTag(tag)
+
+		o, ok := byName[name]
+		if !ok {
+			if flags["required"] {
+				errs = append(errs, OptionError{Option: name, Key: "options.required"})
+			} else if def, ok := field.Tag.Lookup("default"); ok {
+				err := setDefault(v.Field(idx), def)
+				if err != nil {
+					return fmt.Errorf("BindOptions: default for %s: %w", name, err)
+				}
+			}
+			continue
+		}
+
+		if !setOption(s, v.Field(idx), o) {
+			errs = append(errs, OptionError{Option: name, Key: "options.wrong_type"})
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// parseOptionTag splits an option tag like "name,required,autocomplete" into the name and its flags
+func parseOptionTag(tag string) (string, map[string]bool) {
+	name, rest, _ := strings.Cut(tag, ",")
+	flags := map[string]bool{}
+	for _, flag := range strings.Split(rest, ",") {
+		if flag != "" {
+			flags[flag] = true
+		}
+	}
+	return name, flags
+}
+
+var (
+	userType    = reflect.TypeOf(&discordgo.User{})
+	channelType = reflect.TypeOf(&discordgo.Channel{})
+	roleType    = reflect.TypeOf(&discordgo.Role{})
+)
+
+// setOption sets the field to the option's value, returning false if the types don't match
+func setOption(s *discordgo.Session, f reflect.Value, o *discordgo.ApplicationCommandInteractionDataOption) bool {
+	switch o.Type {
+	case discordgo.ApplicationCommandOptionString:
+		if f.Kind() != reflect.String {
+			return false
+		}
+		f.SetString(o.StringValue())
+	case discordgo.ApplicationCommandOptionInteger:
+		switch f.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			f.SetInt(o.IntValue())
+		case reflect.Float32, reflect.Float64:
+			f.SetFloat(float64(o.IntValue()))
+		default:
+			return false
+		}
+	case discordgo.ApplicationCommandOptionNumber:
+		if f.Kind() != reflect.Float32 && f.Kind() != reflect.Float64 {
+			return false
+		}
+		f.SetFloat(o.FloatValue())
+	case discordgo.ApplicationCommandOptionBoolean:
+		if f.Kind() != reflect.Bool {
+			return false
+		}
+		f.SetBool(o.BoolValue())
+	case discordgo.ApplicationCommandOptionUser:
+		if f.Type() != userType {
+			return false
+		}
+		f.Set(reflect.ValueOf(o.UserValue(s)))
+	case discordgo.ApplicationCommandOptionChannel:
+		if f.Type() != channelType {
+			return false
+		}
+		f.Set(reflect.ValueOf(o.ChannelValue(s)))
+	case discordgo.ApplicationCommandOptionRole:
+		if f.Type() != roleType {
+			return false
+		}
+		f.Set(reflect.ValueOf(o.RoleValue(s, "")))
+	default:
+		return false
+	}
+	return true
+}
+
+// setDefault parses the default tag into the field
+func setDefault(f reflect.Value, def string) error {
+	switch f.Kind() {
+	case reflect.String:
+		f.SetString(def)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(def, 10, 64)
+		if err != nil {
+			return err
+		}
+		f.SetInt(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(def, 64)
+		if err != nil {
+			return err
+		}
+		f.SetFloat(n)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(def)
+		if err != nil {
+			return err
+		}
+		f.SetBool(b)
+	default:
+		return fmt.Errorf("unsupported field type %s", f.Type())
+	}
+	return nil
+}
+
+// ValidationErrorEmbed returns an embed listing the options that couldn't be bound
+func ValidationErrorEmbed(locale discordgo.Locale, err error) *discordgo.MessageEmbed {
+	var errs OptionErrors
+	if !errors.As(err, &errs) {
+		return ErrorEmbed(locale, err)
+	}
+	var lines []string
+	for _, oe := range errs {
+		lines = append(lines, "`"+oe.Option+"`: "+i18n.T(locale, oe.Key))
+	}
+	return SimpleEmbed(i18n.T(locale, "options.invalid"), strings.Join(lines, "\n"), EMBED_RED)
+}
+
+// RespondError responds to the interaction with an ephemeral error embed
+func (b *Bot) RespondError(s *discordgo.Session, i *discordgo.InteractionCreate, err error) {
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Flags:  discordgo.MessageFlagsEphemeral,
+			Embeds: []*discordgo.MessageEmbed{ErrorEmbed(i.Locale, err)},
+		},
+	})
+	if err != nil {
+		b.ReportError(i, err)
+	}
+}
+
+// EditResponse edits the interaction's response to show the embed and components, removing other components
+func (b *Bot) EditResponse(s *discordgo.Session, i *discordgo.InteractionCreate, embed *discordgo.MessageEmbed, components ...discordgo.MessageComponent) {
+	if components == nil {
+		components = []discordgo.MessageComponent{}
+	}
+	_, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+		Embeds:     &[]*discordgo.MessageEmbed{embed},
+		Components: &components,
+	})
+	if err != nil {
+		b.ReportError(i, err)
+	}
+}
+
+// RespondValidationError responds to the interaction with a validation error embed
+func (b *Bot) RespondValidationError(s *discordgo.Session, i *discordgo.InteractionCreate, err error) {
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Flags:  discordgo.MessageFlagsEphemeral,
+			Embeds: []*discordgo.MessageEmbed{ValidationErrorEmbed(i.Locale, err)},
+		},
+	})
+	if err != nil {
+		b.ReportError(i, err)
+	}
+}
@@ -0,0 +1,317 @@
+package discord
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NeuralNexusDev/neuralnexus-discord-bot/src/i18n"
+	"github.com/bwmarrin/discordgo"
+)
+
+// DefaultPaginatorTimeout how long paginators work if their timeout isn't set
+const DefaultPaginatorTimeout = 5 * time.Minute
+
+// maxJumpPage largest page number the page jump modal accepts
+const maxJumpPage = 999999
+
+// Paginator component actions, kept short to leave room in custom IDs
+const (
+	pageFirst  = "f"
+	pagePrev   = "p"
+	pageNext   = "n"
+	pageLast   = "l"
+	pageJump   = "j"
+	pageModal  = "m"
+	pageLink   = "g"
+	pageSelect = "s"
+)
+
+// Paginator pages through a list of embeds with first/previous/next/last buttons and a page jump.
+// Its state is carried in the components' custom IDs, so it keeps working across restarts until it expires.
+type Paginator struct {
+	// ID component custom ID, starting with the owning module's name and "_"
+	ID string
+	// Render renders the state's page
+	Render func(b *Bot, i *discordgo.InteractionCreate, state PageState) *Page
+	// Timeout after which the buttons are disabled, DefaultPaginatorTimeout if unset
+	Timeout time.Duration
+}
+
+// Page rendered paginator page
+type Page struct {
+	Embed *discordgo.MessageEmbed
+	// Pages total number of pages
+	Pages int
+	// Components action rows shown above the navigation buttons
+	Components []discordgo.MessageComponent
+}
+
+// PageState paginator state carried in its components' custom IDs
+type PageState struct {
+	// Page zero-based page number
+	Page int
+	// Arg what's being paged through, like a query or a command name
+	Arg string
+	// UserID the only user allowed to use the paginator
+	UserID  string
+	Expires time.Time
+	id      string
+	action  string
+}
+
+// customID encodes the state as "<id>:<action>:<page>:<user>:<expires>:<arg>".
+// The arg is shortened to keep the custom ID within Discord's limit.
+func (st PageState) customID(action string, page int, arg string) string {
+	prefix := strings.Join([]string{
+		st.id,
+		action,
+		strconv.Itoa(page),
+		st.UserID,
+		strconv.FormatInt(st.Expires.Unix(), 36),
+		"",
+	}, ":")
+	if r := []rune(arg); len(prefix)+len(r) > maxComponentIDLength {
+		arg = string(r[:max(0, maxComponentIDLength-len(prefix))])
+	}
+	return prefix + arg
+}
+
+// fitArg returns arg shortened like the custom IDs of any page the paginator can show would shorten it,
+// so every page shows the same arg
+func (st PageState) fitArg(arg string) string {
+	return strings.SplitN(st.customID(pageModal, maxJumpPage, arg), ":", 6)[5]
+}
+
+// LinkID returns the custom ID of a button showing the page of arg
+func (st PageState) LinkID(page int, arg string) string {
+	return st.customID(pageLink, page, arg)
+}
+
+// SelectID returns the custom ID of a select menu showing the first page of the selected value
+func (st PageState) SelectID() string {
+	return st.customID(pageSelect, 0, st.Arg)
+}
+
+// parsePageState decodes a paginator custom ID
+func parsePageState(customID string) (PageState, error) {
+	parts := strings.SplitN(customID, ":", 6)
+	if len(parts) != 6 {
+		return PageState{}, fmt.Errorf("invalid paginator custom ID %q", customID)
+	}
+	page, err := strconv.Atoi(parts[2])
+	if err != nil {
+		return PageState{}, fmt.Errorf("invalid paginator page in %q: %w", customID, err)
+	}
+	expires, err := strconv.ParseInt(parts[4], 36, 64)
+	if err != nil {
+		return PageState{}, fmt.Errorf("invalid paginator expiry in %q: %w", customID, err)
+	}
+	return PageState{
+		id:      parts[0],
+		action:  parts[1],
+		Page:    page,
+		UserID:  parts[3],
+		Expires: time.Unix(expires, 0),
+		Arg:     parts[5],
+	}, nil
+}
+
+// AddPaginator adds the handler for the paginator's components
+func (b *Bot) AddPaginator(p *Paginator) {
+	b.AddComponentHandler(p.ID, p.Handler(b))
+}
+
+func (p *Paginator) timeout() time.Duration {
+	if p.Timeout <= 0 {
+		return DefaultPaginatorTimeout
+	}
+	return p.Timeout
+}
+
+// Respond responds to the interaction with the first page of arg, disabling the buttons once the paginator times out
+func (p *Paginator) Respond(b *Bot, s *discordgo.Session, i *discordgo.InteractionCreate, arg string, flags discordgo.MessageFlags) {
+	state := PageState{
+		UserID:  InteractionUser(i).ID,
+		Expires: time.Now().Add(p.timeout()),
+		id:      p.ID,
+	}
+	state.Arg = state.fitArg(arg)
+	data := p.render(b, i, state)
+	data.Flags = flags
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: data,
+	})
+	if err != nil {
+		b.ReportError(i, err)
+		return
+	}
+
+	if p.timeout() < FollowupWindow {
+		time.AfterFunc(p.timeout(), func() { p.expire(s, i) })
+	}
+}
+
+// Handler returns the handler for the paginator's buttons, page jump modal and select menus
+func (p *Paginator) Handler(b *Bot) InteractionHandler {
+	return func(s *discordgo.Session, i *discordgo.InteractionCreate) {
+		var customID string
+		if i.Type == discordgo.InteractionModalSubmit {
+			customID = i.ModalSubmitData().CustomID
+		} else {
+			customID = i.MessageComponentData().CustomID
+		}
+		state, err := parsePageState(customID)
+		if err != nil {
+			b.ReportError(i, err)
+			return
+		}
+
+		if InteractionUser(i).ID != state.UserID {
+			respondEphemeral(b, s, i, ErrorEmbed(i.Locale, errors.New(i18n.T(i.Locale, "interaction.not_invoker"))))
+			return
+		}
+		if time.Now().After(state.Expires) {
+			var components []discordgo.MessageComponent
+			if i.Message != nil {
+				components = DisableComponents(i.Message.Components)
+			}
+			updateMessage(b, s, i, &discordgo.InteractionResponseData{Components: components})
+			return
+		}
+
+		switch state.action {
+		case pageJump:
+			p.respondJumpModal(b, s, i, state)
+			return
+		case pageModal:
+			page, err := jumpPage(i)
+			if err != nil {
+				respondEphemeral(b, s, i, ErrorEmbed(i.Locale, errors.New(i18n.T(i.Locale, "paginator.invalid_page"))))
+				return
+			}
+			state.Page = page
+		case pageSelect:
+			if values := i.MessageComponentData().Values; len(values) > 0 {
+				state.Arg = state.fitArg(values[0])
+			}
+			state.Page = 0
+		}
+		updateMessage(b, s, i, p.render(b, i, state))
+	}
+}
+
+// render renders the state's page along with the navigation buttons
+func (p *Paginator) render(b *Bot, i *discordgo.InteractionCreate, state PageState) *discordgo.InteractionResponseData {
+	page := p.Render(b, i, state)
+	pages := max(page.Pages, 1)
+	if state.Page < 0 || state.Page >= pages {
+		state.Page = max(0, min(state.Page, pages-1))
+		page = p.Render(b, i, state)
+	}
+
+	if page.Embed.Footer == nil && pages > 1 {
+		page.Embed.Footer = &discordgo.MessageEmbedFooter{Text: i18n.T(i.Locale, "paginator.page", state.Page+1, pages)}
+	}
+	components := page.Components
+	if pages > 1 {
+		first, last := state.Page == 0, state.Page == pages-1
+		components = append(components, ComponentActionRow(
+			discordgo.Button{Label: "⏮", Style: discordgo.SecondaryButton, Disabled: first, CustomID: state.customID(pageFirst, 0, state.Arg)},
+			discordgo.Button{Label: "◀", Style: discordgo.SecondaryButton, Disabled: first, CustomID: state.customID(pagePrev, state.Page-1, state.Arg)},
+			discordgo.Button{Label: "▶", Style: discordgo.SecondaryButton, Disabled: last, CustomID: state.customID(pageNext, state.Page+1, state.Arg)},
+			discordgo.Button{Label: "⏭", Style: discordgo.SecondaryButton, Disabled: last, CustomID: state.customID(pageLast, pages-1, state.Arg)},
+			discordgo.Button{Label: i18n.T(i.Locale, "paginator.jump"), Style: discordgo.SecondaryButton, CustomID: state.customID(pageJump, pages, state.Arg)},
+		))
+	}
+	return &discordgo.InteractionResponseData{
+		Embeds:     []*discordgo.MessageEmbed{page.Embed},
+		Components: components,
+	}
+}
+
+// respondJumpModal asks for the page to jump to, the jump button's page is the number of pages
+func (p *Paginator) respondJumpModal(b *Bot, s *discordgo.Session, i *discordgo.InteractionCreate, state PageState) {
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseModal,
+		Data: &discordgo.InteractionResponseData{
+			CustomID: state.customID(pageModal, state.Page, state.Arg),
+			Title:    i18n.T(i.Locale, "paginator.jump.title"),
+			Components: []discordgo.MessageComponent{
+				ComponentActionRow(discordgo.TextInput{
+					CustomID:  "page",
+					Label:     i18n.T(i.Locale, "paginator.jump.label", state.Page),
+					Style:     discordgo.TextInputShort,
+					Required:  true,
+					MaxLength: len(strconv.Itoa(maxJumpPage)),
+				}),
+			},
+		},
+	})
+	if err != nil {
+		b.ReportError(i, err)
+	}
+}
+
+// jumpPage returns the zero-based page entered in the page jump modal
+func jumpPage(i *discordgo.InteractionCreate) (int, error) {
+	for _, c := range i.ModalSubmitData().Components {
+		row, ok := c.(*discordgo.ActionsRow)
+		if !ok {
+			continue
+		}
+		for _, rc := range row.Components {
+			if input, ok := rc.(*discordgo.TextInput); ok && input.CustomID == "page" {
+				page, err := strconv.Atoi(strings.TrimSpace(input.Value))
+				if err != nil || page < 1 {
+					return 0, fmt.Errorf("invalid page %q", input.Value)
+				}
+				return page - 1, nil
+			}
+		}
+	}
+	return 0, errors.New("missing page input")
+}
+
+// expire disables the buttons of the interaction's response
+func (p *Paginator) expire(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	msg, err := s.InteractionResponse(i.Interaction)
+	if err != nil {
+		log.Printf("Cannot fetch paginator %s message: %v", p.ID, err)
+		return
+	}
+	components := DisableComponents(msg.Components)
+	_, err = s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{Components: &components})
+	if err != nil {
+		log.Printf("Cannot disable paginator %s buttons: %v", p.ID, err)
+	}
+}
+
+// updateMessage updates the message the component belongs to
+func updateMessage(b *Bot, s *discordgo.Session, i *discordgo.InteractionCreate, data *discordgo.InteractionResponseData) {
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: data,
+	})
+	if err != nil {
+		b.ReportError(i, err)
+	}
+}
+
+// respondEphemeral responds to the interaction with an embed only the user can see
+func respondEphemeral(b *Bot, s *discordgo.Session, i *discordgo.InteractionCreate, embed *discordgo.MessageEmbed) {
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Flags:  discordgo.MessageFlagsEphemeral,
+			Embeds: []*discordgo.MessageEmbed{embed},
+		},
+	})
+	if err != nil {
+		b.ReportError(i, err)
+	}
+}
@@ -0,0 +1,46 @@
+package discord
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPageStateCustomID(t *testing.T) {
+	state := PageState{
+		UserID:  "123456789012345678",
+		Expires: time.Unix(1700000000, 0),
+		id:      "help_page",
+	}
+	tests := []struct {
+		name string
+		arg  string
+	}{
+		{"empty", ""},
+		{"short", "beename"},
+		{"with separator", "a:b"},
+		{"too long", strings.Repeat("a", 120)},
+		{"multibyte", strings.Repeat("ä", 120)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state.Arg = state.fitArg(tt.arg)
+			if !strings.HasPrefix(tt.arg, state.Arg) {
+				t.Fatalf("fitArg() = %q, want a prefix of %q", state.Arg, tt.arg)
+			}
+			for _, page := range []int{0, 42, maxJumpPage} {
+				id := state.customID(pageModal, page, state.Arg)
+				if n := len([]rune(id)); n > maxComponentIDLength {
+					t.Errorf("customID() is %d characters, want at most %d", n, maxComponentIDLength)
+				}
+				parsed, err := parsePageState(id)
+				if err != nil {
+					t.Fatalf("parsePageState() error = %v", err)
+				}
+				if parsed.Arg != state.Arg || parsed.Page != page || parsed.UserID != state.UserID || !parsed.Expires.Equal(state.Expires) {
+					t.Errorf("parsePageState() = %+v, want %+v on page %d", parsed, state, page)
+				}
+			}
+		})
+	}
+}
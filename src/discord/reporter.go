@@ -0,0 +1,294 @@
+package discord
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NeuralNexusDev/neuralnexus-discord-bot/src/api"
+	"github.com/bwmarrin/discordgo"
+)
+
+//goland:noinspection GoSnakeCaseUsage
+var (
+	ERROR_CHANNEL_ID  = os.Getenv("ERROR_CHANNEL_ID")
+	ERROR_WEBHOOK_URL = os.Getenv("ERROR_WEBHOOK_URL")
+)
+
+const (
+	// errorDedupWindow identical errors within this window are counted instead of posted
+	errorDedupWindow = 10 * time.Minute
+	// errorBurst reports that can be posted at once
+	errorBurst = 5
+	// errorRefill time for one report to be refilled
+	errorRefill = 30 * time.Second
+	// maxErrorLength error message characters kept so reports fit in an embed
+	maxErrorLength = 900
+	// maxStackLength stack trace characters kept so reports fit in an embed
+	maxStackLength = 3000
+	// maxTitleLength characters Discord allows in an embed title
+	maxTitleLength = 256
+	// maxFieldLength characters Discord allows in an embed field value
+	maxFieldLength = 1024
+)
+
+// ErrorReport error to be reported
+type ErrorReport struct {
+	// Source where the error happened, e.g. "command /mcstatus" or "job cache-refresh"
+	Source string
+	Err    error
+	// Panic whether the error was recovered from a panic
+	Panic bool
+	// Stack stack trace of the panic
+	Stack []byte
+	// Interaction interaction being handled, if any
+	Interaction *discordgo.InteractionCreate
+	// TraceID trace of the interaction, if it was traced
+	TraceID string
+}
+
+type seenError struct {
+	last       time.Time
+	suppressed int
+}
+
+// ErrorReporter posts errors to a private channel or webhook, deduplicating repeats and rate limiting bursts
+type ErrorReporter struct {
+	s            *discordgo.Session
+	channelID    string
+	webhookID    string
+	webhookToken string
+
+	mu      sync.Mutex
+	seen    map[string]*seenError
+	tokens  float64
+	refill  time.Time
+	dropped int
+}
+
+// NewErrorReporter returns a reporter posting to the webhook if set, otherwise the channel.
+// Reports are only logged if neither is set.
+func NewErrorReporter(s *discordgo.Session, channelID, webhookURL string) (*ErrorReporter, error) {
+	r := &ErrorReporter{
+		s:         s,
+		channelID: channelID,
+		seen:      map[string]*seenError{},
+		tokens:    errorBurst,
+		refill:    time.Now(),
+	}
+	if webhookURL != "" {
+		_, path, ok := strings.Cut(webhookURL, "/api/webhooks/")
+		id, token, _ := strings.Cut(path, "/")
+		if !ok || id == "" || token == "" {
+			return nil, errors.New("invalid error webhook URL")
+		}
+		r.webhookID, r.webhookToken = id, token
+	}
+	return r, nil
+}
+
+// Report logs the error and posts it unless it's a recent duplicate or the rate limit is exceeded
+func (r *ErrorReporter) Report(report ErrorReport) {
+	log.Printf("Error in %s: %v", report.Source, report.Err)
+	if report.Panic {
+		log.Printf("%s", report.Stack)
+	}
+	if r.channelID == "" && r.webhookID == "" {
+		return
+	}
+
+	suppressed, dropped, ok := r.allow(report.Source + ": " + report.Err.Error())
+	if !ok {
+		return
+	}
+	go r.post(errorEmbed(report, suppressed, dropped))
+}
+
+// allow applies deduplication and the rate limit, returning how many duplicates and reports were held back since the last post
+func (r *ErrorReporter) allow(fingerprint string) (int, int, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	for k, e := range r.seen {
+		if now.Sub(e.last) > errorDedupWindow {
+			if e.suppressed > 0 {
+				log.Printf("Error repeated %d more times without being reported: %s", e.suppressed, k)
+			}
+			delete(r.seen, k)
+		}
+	}
+	e, ok := r.seen[fingerprint]
+	if ok && now.Sub(e.last) < errorDedupWindow {
+		e.suppressed++
+		return 0, 0, false
+	}
+
+	r.tokens = min(errorBurst, r.tokens+now.Sub(r.refill).Seconds()/errorRefill.Seconds())
+	r.refill = now
+	if r.tokens < 1 {
+		r.dropped++
+		return 0, 0, false
+	}
+	r.tokens--
+
+	suppressed := 0
+	if ok {
+		suppressed = e.suppressed
+	}
+	r.seen[fingerprint] = &seenError{last: now}
+	dropped := r.dropped
+	r.dropped = 0
+	return suppressed, dropped, true
+}
+
+func (r *ErrorReporter) post(embed *discordgo.MessageEmbed) {
+	var err error
+	if r.webhookID != "" {
+		_, err = r.s.WebhookExecute(r.webhookID, r.webhookToken, false, &discordgo.WebhookParams{
+			Embeds: []*discordgo.MessageEmbed{embed},
+		})
+	} else {
+		_, err = r.s.ChannelMessageSendEmbed(r.channelID, embed)
+	}
+	if err != nil {
+		log.Printf("Cannot post error report: %v", err)
+	}
+}
+
+// errorEmbed builds the report embed with the interaction's context and stack trace
+func errorEmbed(report ErrorReport, suppressed, dropped int) *discordgo.MessageEmbed {
+	title := "Error in " + report.Source
+	if report.Panic {
+		title = "Panic in " + report.Source
+	}
+	description := "```\n" + truncate(report.Err.Error(), maxErrorLength) + "\n```"
+	if len(report.Stack) > 0 {
+		description += "\n```go\n" + truncate(string(report.Stack), maxStackLength) + "\n```"
+	}
+
+	embed := SimpleEmbed(truncate(title, maxTitleLength), description, EMBED_RED)
+	embed.Timestamp = time.Now().Format(time.RFC3339)
+	if i := report.Interaction; i != nil {
+		user := i.User
+		if i.Member != nil {
+			user = i.Member.User
+		}
+		if user != nil {
+			embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{Name: "User", Value: "<@" + user.ID + "> (" + user.ID + ")", Inline: true})
+		}
+		guild := "DM"
+		if i.GuildID != "" {
+			guild = i.GuildID
+		}
+		embed.Fields = append(embed.Fields,
+			&discordgo.MessageEmbedField{Name: "Guild", Value: guild, Inline: true},
+			&discordgo.MessageEmbedField{Name: "Channel", Value: "<#" + i.ChannelID + ">", Inline: true},
+			&discordgo.MessageEmbedField{Name: "Interaction", Value: "`" + truncate(describeInteraction(i), maxFieldLength-2) + "`"},
+		)
+	}
+	if report.TraceID != "" {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{Name: "Trace", Value: "`" + report.TraceID + "`"})
+	}
+	var notes []string
+	if suppressed > 0 {
+		notes = append(notes, fmt.Sprintf("repeated %d more times since last report", suppressed))
+	}
+	if dropped > 0 {
+		notes = append(notes, fmt.Sprintf("%d other reports dropped by rate limit", dropped))
+	}
+	if len(notes) > 0 {
+		embed.Footer = &discordgo.MessageEmbedFooter{Text: strings.Join(notes, ", ")}
+	}
+	return embed
+}
+
+// truncate shortens s to n characters, without splitting a multibyte character
+func truncate(s string, n int) string {
+	if r := []rune(s); len(r) > n {
+		return string(r[:n-1]) + "…"
+	}
+	return s
+}
+
+// describeInteraction returns a short description of the interaction, like "/beename upload name:Bob"
+func describeInteraction(i *discordgo.InteractionCreate) string {
+	switch i.Type {
+	case discordgo.InteractionApplicationCommand:
+		data := i.ApplicationCommandData()
+		return "/" + data.Name + describeOptions(data.Options)
+	case discordgo.InteractionMessageComponent:
+		return "component " + i.MessageComponentData().CustomID
+	case discordgo.InteractionModalSubmit:
+		return "modal " + i.ModalSubmitData().CustomID
+	}
+	return i.Type.String()
+}
+
+func describeOptions(options []*discordgo.ApplicationCommandInteractionDataOption) string {
+	var sb strings.Builder
+	for _, o := range options {
+		switch o.Type {
+		case discordgo.ApplicationCommandOptionSubCommand, discordgo.ApplicationCommandOptionSubCommandGroup:
+			sb.WriteString(" " + o.Name + describeOptions(o.Options))
+		default:
+			sb.WriteString(fmt.Sprintf(" %s:%v", o.Name, o.Value))
+		}
+	}
+	return sb.String()
+}
+
+// ReportError reports an error that happened while handling an interaction.
+// Expected errors users are already told about, like a bee name that doesn't exist, aren't reported.
+func (b *Bot) ReportError(i *discordgo.InteractionCreate, err error) {
+	if expectedError(err) {
+		return
+	}
+	b.Errors.Report(ErrorReport{
+		Source:      describeInteraction(i),
+		Err:         err,
+		Interaction: i,
+		TraceID:     traceID(b.recordSpanError(i, err)),
+	})
+}
+
+// expectedError reports whether the error is an expected outcome rather than a bug or outage: invalid input,
+// NeuralNexus API client errors like ErrNotFound, and requests the open circuit breaker didn't send.
+// Server errors, transport failures and the API rejecting the bot's key are unexpected.
+func expectedError(err error) bool {
+	var apiErr *api.Error
+	switch {
+	case errors.Is(err, api.ErrInvalidInput), errors.Is(err, api.ErrCircuitOpen):
+		return true
+	case errors.As(err, &apiErr):
+		return apiErr.StatusCode >= http.StatusBadRequest && apiErr.StatusCode < http.StatusInternalServerError &&
+			!errors.Is(err, api.ErrUnauthorized)
+	}
+	return false
+}
+
+// recoverInteraction reports a panic raised while handling an interaction, must be deferred
+func (b *Bot) recoverInteraction(i *discordgo.InteractionCreate) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	err, ok := r.(error)
+	if !ok {
+		err = fmt.Errorf("%v", r)
+	}
+	b.Errors.Report(ErrorReport{
+		Source:      describeInteraction(i),
+		Err:         err,
+		Panic:       true,
+		Stack:       debug.Stack(),
+		Interaction: i,
+		TraceID:     traceID(b.recordSpanError(i, err)),
+	})
+}
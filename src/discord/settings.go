@@ -0,0 +1,94 @@
+package discord
+
+import (
+	"errors"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/NeuralNexusDev/neuralnexus-discord-bot/src/storage"
+)
+
+//goland:noinspection GoSnakeCaseUsage
+var (
+	DATA_DIR      = envOrDefault("DATA_DIR", "data")
+	DATABASE_PATH = envOrDefault("DATABASE_PATH", filepath.Join(DATA_DIR, "bot.db"))
+)
+
+// GameServer default game server address
+type GameServer struct {
+	Game string `json:"game"`
+	Host string `json:"host"`
+	Port int64  `json:"port"`
+}
+
+// GuildSettings per guild bot settings
+type GuildSettings struct {
+	GuildID             string      `json:"guild_id"`
+	DisabledModules     []string    `json:"disabled_modules,omitempty"`
+	LogChannelID        string      `json:"log_channel_id,omitempty"`
+	ModerationChannelID string      `json:"moderation_channel_id,omitempty"`
+	AuditChannelID      string      `json:"audit_channel_id,omitempty"`
+	MCServer            string      `json:"mc_server,omitempty"`
+	MCServerBedrock     bool        `json:"mc_server_bedrock,omitempty"`
+	GameServer          *GameServer `json:"game_server,omitempty"`
+}
+
+// ModuleEnabled checks if the module is enabled for the guild
+func (gs *GuildSettings) ModuleEnabled(module string) bool {
+	return !slices.Contains(gs.DisabledModules, module)
+}
+
+// SetModuleEnabled enables or disables the module for the guild
+func (gs *GuildSettings) SetModuleEnabled(module string, enabled bool) {
+	gs.DisabledModules = slices.DeleteFunc(gs.DisabledModules, func(m string) bool { return m == module })
+	if !enabled {
+		gs.DisabledModules = append(gs.DisabledModules, module)
+	}
+}
+
+// GuildSettingsRepository guild settings repository
+type GuildSettingsRepository struct {
+	repo *storage.Repository[GuildSettings]
+}
+
+// NewGuildSettingsRepository returns a guild settings repository backed by the store
+func NewGuildSettingsRepository(store storage.Store) *GuildSettingsRepository {
+	return &GuildSettingsRepository{
+		repo: storage.NewRepository[GuildSettings](store, GuildSettingsBucket),
+	}
+}
+
+// Get returns the guild's settings, or the defaults if none are stored or they can't be read
+func (r *GuildSettingsRepository) Get(guildID string) *GuildSettings {
+	gs, err := r.repo.Get(guildID)
+	if err != nil {
+		if !errors.Is(err, storage.ErrNotFound) {
+			log.Printf("Cannot read settings for guild %s: %v", guildID, err)
+		}
+		return &GuildSettings{GuildID: guildID}
+	}
+	return gs
+}
+
+// Update applies fn to the guild's settings and persists the result
+func (r *GuildSettingsRepository) Update(guildID string, fn func(gs *GuildSettings)) (*GuildSettings, error) {
+	return r.repo.Update(guildID, func(gs *GuildSettings) error {
+		gs.GuildID = guildID
+		fn(gs)
+		return nil
+	})
+}
+
+// Delete removes the guild's settings
+func (r *GuildSettingsRepository) Delete(guildID string) error {
+	return r.repo.Delete(guildID)
+}
+
+func envOrDefault(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
@@ -0,0 +1,84 @@
+package discord
+
+import (
+	"context"
+	"strings"
+
+	"github.com/NeuralNexusDev/neuralnexus-discord-bot/src/telemetry"
+	"github.com/bwmarrin/discordgo"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// startInteractionSpan starts the span covering the interaction, from when Discord created it until it's handled.
+// Until the returned function ends it, ResponseContext and FollowupContext derive from the span's context.
+func (b *Bot) startInteractionSpan(i *discordgo.InteractionCreate) func() {
+	ctx, span := telemetry.Tracer().Start(b.ctx, interactionSpanName(i),
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithTimestamp(interactionCreated(i)),
+		trace.WithAttributes(
+			attribute.String("discord.interaction.id", i.ID),
+			attribute.String("discord.interaction.type", i.Type.String()),
+			attribute.String("discord.guild.id", i.GuildID),
+			attribute.String("discord.channel.id", i.ChannelID),
+			attribute.String("discord.locale", string(i.Locale)),
+		),
+	)
+	b.interactions.Store(i.ID, ctx)
+	return func() {
+		b.interactions.Delete(i.ID)
+		span.End()
+	}
+}
+
+// runHandler runs the interaction's handler in a child span of the interaction span
+func (b *Bot) runHandler(h InteractionHandler, s *discordgo.Session, i *discordgo.InteractionCreate) {
+	parent := b.interactionContext(i)
+	ctx, span := telemetry.Tracer().Start(parent, "handler")
+	defer span.End()
+	b.interactions.Store(i.ID, ctx)
+	defer b.interactions.Store(i.ID, parent)
+	h(s, i)
+}
+
+// interactionContext returns the context of the interaction's current span, or the bot's if it isn't traced
+func (b *Bot) interactionContext(i *discordgo.InteractionCreate) context.Context {
+	if ctx, ok := b.interactions.Load(i.ID); ok {
+		return ctx.(context.Context)
+	}
+	return b.ctx
+}
+
+// recordSpanError marks the interaction's current span as failed
+func (b *Bot) recordSpanError(i *discordgo.InteractionCreate, err error) trace.SpanContext {
+	span := trace.SpanFromContext(b.interactionContext(i))
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+	return span.SpanContext()
+}
+
+// interactionSpanName names the span after the command or component handler, leaving out user input
+func interactionSpanName(i *discordgo.InteractionCreate) string {
+	switch i.Type {
+	case discordgo.InteractionApplicationCommand:
+		return "/" + i.ApplicationCommandData().Name
+	case discordgo.InteractionApplicationCommandAutocomplete:
+		return "autocomplete /" + i.ApplicationCommandData().Name
+	case discordgo.InteractionMessageComponent:
+		id, _, _ := strings.Cut(i.MessageComponentData().CustomID, ":")
+		return "component " + id
+	case discordgo.InteractionModalSubmit:
+		id, _, _ := strings.Cut(i.ModalSubmitData().CustomID, ":")
+		return "modal " + id
+	}
+	return i.Type.String()
+}
+
+// traceID returns the span's trace ID, empty if it isn't sampled
+func traceID(sc trace.SpanContext) string {
+	if !sc.IsSampled() {
+		return ""
+	}
+	return sc.TraceID().String()
+}
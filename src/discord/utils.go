@@ -1,6 +1,11 @@
 package discord
 
 import (
+	"errors"
+	"time"
+
+	"github.com/NeuralNexusDev/neuralnexus-discord-bot/src/api"
+	"github.com/NeuralNexusDev/neuralnexus-discord-bot/src/i18n"
 	"github.com/bwmarrin/discordgo"
 )
 
@@ -14,6 +19,8 @@ const (
 var (
 	DMPermissionTrue  = true
 	DMPermissionFalse = false
+
+	ManageServerPermission int64 = discordgo.PermissionManageServer
 )
 
 // SimpleEmbed returns a new embed
@@ -26,33 +33,147 @@ func SimpleEmbed(title, description string, color int) *discordgo.MessageEmbed {
 }
 
 // ErrorSuccessEmbed returns an error or success embed
-func ErrorSuccessEmbed(err error, success string) *discordgo.MessageEmbed {
+func ErrorSuccessEmbed(locale discordgo.Locale, err error, success string) *discordgo.MessageEmbed {
 	if err != nil {
-		return &discordgo.MessageEmbed{
-			Title:       "Error",
-			Description: err.Error(),
-			Color:       EMBED_RED,
-		}
+		return ErrorEmbed(locale, err)
 	}
 	return &discordgo.MessageEmbed{
-		Title:       "Success",
+		Title:       i18n.T(locale, "embed.success.title"),
 		Description: success,
 		Color:       EMBED_GREEN,
 	}
 }
 
-// ErrorEmbed returns an error embed
-func ErrorEmbed(err error) *discordgo.MessageEmbed {
+// ErrorEmbed returns an error embed, or a degraded mode notice if the NeuralNexus API is unavailable
+func ErrorEmbed(locale discordgo.Locale, err error) *discordgo.MessageEmbed {
+	if errors.Is(err, api.ErrCircuitOpen) {
+		return SimpleEmbed(i18n.T(locale, "api.unavailable.title"), ErrorMessage(locale, err), EMBED_YELLOW)
+	}
 	return &discordgo.MessageEmbed{
-		Title:       "Error",
-		Description: err.Error(),
+		Title:       i18n.T(locale, "embed.error.title"),
+		Description: ErrorMessage(locale, err),
 		Color:       EMBED_RED,
 	}
 }
 
+// ErrorMessage returns the message to show users for the error, explaining NeuralNexus API failures
+func ErrorMessage(locale discordgo.Locale, err error) string {
+	var apiErr *api.Error
+	if !errors.As(err, &apiErr) {
+		return err.Error()
+	}
+
+	var message string
+	switch {
+	case errors.Is(err, api.ErrCircuitOpen):
+		message = i18n.T(locale, "api.unavailable.description")
+	case errors.Is(err, api.ErrNotFound):
+		message = apiErr.Detail
+		if message == "" {
+			message = i18n.T(locale, "api.error.not_found")
+		}
+	case errors.Is(err, api.ErrUnauthorized):
+		message = i18n.T(locale, "api.error.unauthorized")
+	case errors.Is(err, api.ErrRateLimited):
+		message = i18n.T(locale, "api.error.rate_limited")
+	case errors.Is(err, api.ErrUnavailable):
+		message = i18n.T(locale, "api.error.unavailable")
+	default:
+		message = apiErr.Detail
+		if message == "" {
+			message = i18n.T(locale, "api.error.unknown", apiErr.StatusCode)
+		}
+	}
+	if apiErr.RequestID != "" {
+		message += "\n" + i18n.T(locale, "api.error.request_id", apiErr.RequestID)
+	}
+	return message
+}
+
+// CacheAge returns how long ago a cached response was fetched, or "" if it's fresh
+func CacheAge(locale discordgo.Locale, fetchedAt time.Time) string {
+	age := time.Since(fetchedAt)
+	if age < time.Second {
+		return ""
+	}
+	return i18n.T(locale, "cache.age", int(age.Seconds()))
+}
+
+// RefreshComponents returns an action row with a button refreshing a cached response,
+// or no components if the custom ID is too long
+func RefreshComponents(locale discordgo.Locale, customID string) []discordgo.MessageComponent {
+	if len(customID) > maxComponentIDLength {
+		return []discordgo.MessageComponent{}
+	}
+	return []discordgo.MessageComponent{
+		ComponentActionRow(discordgo.Button{
+			Label:    i18n.T(locale, "cache.refresh"),
+			Emoji:    &discordgo.ComponentEmoji{Name: "🔄"},
+			Style:    discordgo.SecondaryButton,
+			CustomID: customID,
+		}),
+	}
+}
+
 // ComponentActionRow component action row
 func ComponentActionRow(components ...discordgo.MessageComponent) discordgo.ActionsRow {
 	return discordgo.ActionsRow{
 		Components: components,
 	}
 }
+
+// GuildLocale returns the guild's preferred locale, or the default locale outside of guilds
+func GuildLocale(i *discordgo.InteractionCreate) discordgo.Locale {
+	if i.GuildLocale != nil {
+		return *i.GuildLocale
+	}
+	return i18n.DefaultLocale
+}
+
+// InteractionUser returns the user who triggered the interaction, in guilds or DMs
+func InteractionUser(i *discordgo.InteractionCreate) *discordgo.User {
+	if i.Member != nil {
+		return i.Member.User
+	}
+	return i.User
+}
+
+// DisableComponents returns copies of the action rows with their buttons and select menus disabled
+func DisableComponents(components []discordgo.MessageComponent) []discordgo.MessageComponent {
+	var disabled []discordgo.MessageComponent
+	for _, c := range components {
+		var row discordgo.ActionsRow
+		switch r := c.(type) {
+		case discordgo.ActionsRow:
+			row = r
+		case *discordgo.ActionsRow:
+			row = *r
+		default:
+			disabled = append(disabled, c)
+			continue
+		}
+
+		var rowComponents []discordgo.MessageComponent
+		for _, rc := range row.Components {
+			switch v := rc.(type) {
+			case discordgo.Button:
+				v.Disabled = true
+				rc = v
+			case *discordgo.Button:
+				b := *v
+				b.Disabled = true
+				rc = b
+			case discordgo.SelectMenu:
+				v.Disabled = true
+				rc = v
+			case *discordgo.SelectMenu:
+				m := *v
+				m.Disabled = true
+				rc = m
+			}
+			rowComponents = append(rowComponents, rc)
+		}
+		disabled = append(disabled, ComponentActionRow(rowComponents...))
+	}
+	return disabled
+}
@@ -0,0 +1,238 @@
+package discord
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Discord's application command limits
+const (
+	maxCommands             = 100
+	maxCommandOptions       = 25
+	maxOptionChoices        = 25
+	maxDescriptionLength    = 100
+	maxChoiceLength         = 100
+	maxCommandCharacters    = 4000
+	maxStringOptionLength   = 6000
+	maxComponentIDLength    = 100
+	componentStateSeparator = ":"
+)
+
+// commandNamePattern valid chat command and option names
+var commandNamePattern = regexp.MustCompile(`^[-_\p{L}\p{N}\p{Devanagari}\p{Thai}]{1,32}$`)
+
+// ValidateCommands checks the commands and the component handlers against Discord's limits, returning every problem found
+func (b *Bot) ValidateCommands() error {
+	var errs []error
+	if len(b.commands) > maxCommands {
+		errs = append(errs, fmt.Errorf("%d commands, at most %d are allowed", len(b.commands), maxCommands))
+	}
+	names := map[string]bool{}
+	for _, cmd := range b.commands {
+		if names[cmd.Name] {
+			errs = append(errs, fmt.Errorf("/%s: duplicate command name", cmd.Name))
+		}
+		names[cmd.Name] = true
+		errs = append(errs, ValidateCommand(cmd)...)
+	}
+
+	for _, id := range b.duplicateComponents {
+		errs = append(errs, fmt.Errorf("component %q: registered more than once", id))
+	}
+	for id := range b.componentHandlers {
+		if len(id) > maxComponentIDLength {
+			errs = append(errs, fmt.Errorf("component %q: custom ID longer than %d characters", id, maxComponentIDLength))
+		}
+		if strings.Contains(id, componentStateSeparator) {
+			errs = append(errs, fmt.Errorf("component %q: custom ID contains the state separator %q", id, componentStateSeparator))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ValidateCommand checks the chat command against Discord's limits
+func ValidateCommand(cmd *discordgo.ApplicationCommand) []error {
+	path := "/" + cmd.Name
+	errs := validateName(path, cmd.Name)
+	errs = append(errs, validateDescription(path, cmd.Description)...)
+	if cmd.NameLocalizations != nil {
+		for locale, name := range *cmd.NameLocalizations {
+			errs = append(errs, validateName(path+" ("+string(locale)+")", name)...)
+		}
+	}
+	if cmd.DescriptionLocalizations != nil {
+		for locale, description := range *cmd.DescriptionLocalizations {
+			errs = append(errs, validateDescription(path+" ("+string(locale)+")", description)...)
+		}
+	}
+	errs = append(errs, validateOptions(path, cmd.Options, 0)...)
+
+	if n := commandCharacters(cmd); n > maxCommandCharacters {
+		errs = append(errs, fmt.Errorf("%s: names, descriptions and choices total %d characters, at most %d are allowed", path, n, maxCommandCharacters))
+	}
+	return errs
+}
+
+// validateOptions checks one level of options; depth is 0 for the command's options
+func validateOptions(path string, options []*discordgo.ApplicationCommandOption, depth int) []error {
+	var errs []error
+	if len(options) > maxCommandOptions {
+		errs = append(errs, fmt.Errorf("%s: %d options, at most %d are allowed", path, len(options), maxCommandOptions))
+	}
+
+	names := map[string]bool{}
+	optional := ""
+	subcommands := 0
+	for _, o := range options {
+		optionPath := path + " " + o.Name
+		if names[o.Name] {
+			errs = append(errs, fmt.Errorf("%s: duplicate option name", optionPath))
+		}
+		names[o.Name] = true
+
+		errs = append(errs, validateName(optionPath, o.Name)...)
+		errs = append(errs, validateDescription(optionPath, o.Description)...)
+		for locale, name := range o.NameLocalizations {
+			errs = append(errs, validateName(optionPath+" ("+string(locale)+")", name)...)
+		}
+		for locale, description := range o.DescriptionLocalizations {
+			errs = append(errs, validateDescription(optionPath+" ("+string(locale)+")", description)...)
+		}
+
+		switch o.Type {
+		case discordgo.ApplicationCommandOptionSubCommandGroup:
+			subcommands++
+			if depth > 0 {
+				errs = append(errs, fmt.Errorf("%s: subcommand groups can only be nested in commands", optionPath))
+			}
+			for _, sub := range o.Options {
+				if sub.Type != discordgo.ApplicationCommandOptionSubCommand {
+					errs = append(errs, fmt.Errorf("%s %s: subcommand groups can only contain subcommands", optionPath, sub.Name))
+				}
+			}
+			errs = append(errs, validateOptions(optionPath, o.Options, depth+1)...)
+		case discordgo.ApplicationCommandOptionSubCommand:
+			subcommands++
+			if depth > 1 {
+				errs = append(errs, fmt.Errorf("%s: subcommands can only be nested in commands or subcommand groups", optionPath))
+			}
+			errs = append(errs, validateOptions(optionPath, o.Options, depth+1)...)
+		default:
+			if o.Required && optional != "" {
+				errs = append(errs, fmt.Errorf("%s: required options must come before optional ones like %q", optionPath, optional))
+			}
+			if !o.Required && optional == "" {
+				optional = o.Name
+			}
+			errs = append(errs, validateValueOption(optionPath, o)...)
+		}
+	}
+	if subcommands > 0 && subcommands < len(options) {
+		errs = append(errs, fmt.Errorf("%s: subcommands can't be mixed with other options", path))
+	}
+	return errs
+}
+
+// validateValueOption checks a non-subcommand option's choices and bounds
+func validateValueOption(path string, o *discordgo.ApplicationCommandOption) []error {
+	var errs []error
+	if len(o.Options) > 0 {
+		errs = append(errs, fmt.Errorf("%s: only subcommands and groups can have options", path))
+	}
+	if len(o.Choices) > maxOptionChoices {
+		errs = append(errs, fmt.Errorf("%s: %d choices, at most %d are allowed", path, len(o.Choices), maxOptionChoices))
+	}
+	if len(o.Choices) > 0 && o.Autocomplete {
+		errs = append(errs, fmt.Errorf("%s: options can't have both choices and autocomplete", path))
+	}
+
+	choices := map[string]bool{}
+	for _, c := range o.Choices {
+		if choices[c.Name] {
+			errs = append(errs, fmt.Errorf("%s: duplicate choice %q", path, c.Name))
+		}
+		choices[c.Name] = true
+		if n := utf8.RuneCountInString(c.Name); n < 1 || n > maxChoiceLength {
+			errs = append(errs, fmt.Errorf("%s: choice %q name must be 1-%d characters", path, c.Name, maxChoiceLength))
+		}
+		for locale, name := range c.NameLocalizations {
+			if n := utf8.RuneCountInString(name); n < 1 || n > maxChoiceLength {
+				errs = append(errs, fmt.Errorf("%s: choice %q (%s) name must be 1-%d characters", path, c.Name, locale, maxChoiceLength))
+			}
+		}
+
+		switch v := c.Value.(type) {
+		case string:
+			if o.Type != discordgo.ApplicationCommandOptionString {
+				errs = append(errs, fmt.Errorf("%s: choice %q has a string value for a non-string option", path, c.Name))
+			} else if utf8.RuneCountInString(v) > maxChoiceLength {
+				errs = append(errs, fmt.Errorf("%s: choice %q value longer than %d characters", path, c.Name, maxChoiceLength))
+			}
+		case int, int64, float64:
+			if o.Type != discordgo.ApplicationCommandOptionInteger && o.Type != discordgo.ApplicationCommandOptionNumber {
+				errs = append(errs, fmt.Errorf("%s: choice %q has a numeric value for a non-numeric option", path, c.Name))
+			}
+		default:
+			errs = append(errs, fmt.Errorf("%s: choice %q has an unsupported value type %T", path, c.Name, v))
+		}
+	}
+
+	maxValue, hasMax := optionMax(o)
+	if o.Type != discordgo.ApplicationCommandOptionString {
+		if o.MinValue != nil && hasMax && *o.MinValue > maxValue {
+			errs = append(errs, fmt.Errorf("%s: min value %v is larger than max value %v", path, *o.MinValue, maxValue))
+		}
+		return errs
+	}
+	if o.MinLength != nil && (*o.MinLength < 0 || *o.MinLength > maxStringOptionLength) {
+		errs = append(errs, fmt.Errorf("%s: min length must be 0-%d", path, maxStringOptionLength))
+	}
+	if hasMax && (o.MaxLength < 1 || o.MaxLength > maxStringOptionLength) {
+		errs = append(errs, fmt.Errorf("%s: max length must be 1-%d", path, maxStringOptionLength))
+	}
+	if o.MinLength != nil && hasMax && *o.MinLength > o.MaxLength {
+		errs = append(errs, fmt.Errorf("%s: min length %d is larger than max length %d", path, *o.MinLength, o.MaxLength))
+	}
+	return errs
+}
+
+// validateName checks a chat command or option name
+func validateName(path, name string) []error {
+	if !commandNamePattern.MatchString(name) {
+		return []error{fmt.Errorf("%s: name %q must be 1-32 letters, numbers, - or _", path, name)}
+	}
+	if strings.ToLower(name) != name {
+		return []error{fmt.Errorf("%s: name %q must be lowercase", path, name)}
+	}
+	return nil
+}
+
+// validateDescription checks a chat command or option description
+func validateDescription(path, description string) []error {
+	if n := utf8.RuneCountInString(description); n < 1 || n > maxDescriptionLength {
+		return []error{fmt.Errorf("%s: description must be 1-%d characters, got %d", path, maxDescriptionLength, n)}
+	}
+	return nil
+}
+
+// commandCharacters counts the characters Discord limits per command: names, descriptions and choices
+func commandCharacters(cmd *discordgo.ApplicationCommand) int {
+	n := utf8.RuneCountInString(cmd.Name) + utf8.RuneCountInString(cmd.Description)
+	var count func(options []*discordgo.ApplicationCommandOption)
+	count = func(options []*discordgo.ApplicationCommandOption) {
+		for _, o := range options {
+			n += utf8.RuneCountInString(o.Name) + utf8.RuneCountInString(o.Description)
+			for _, c := range o.Choices {
+				n += utf8.RuneCountInString(c.Name) + utf8.RuneCountInString(fmt.Sprint(c.Value))
+			}
+			count(o.Options)
+		}
+	}
+	count(cmd.Options)
+	return n
+}
@@ -0,0 +1,155 @@
+package discord
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// validOption returns a valid option of the type, for tests to break
+func validOption(name string, typ discordgo.ApplicationCommandOptionType) *discordgo.ApplicationCommandOption {
+	return &discordgo.ApplicationCommandOption{
+		Name:        name,
+		Description: "An option",
+		Type:        typ,
+	}
+}
+
+func TestValidateCommand(t *testing.T) {
+	zero, one, two := 0.0, 1.0, 2.0
+	tests := []struct {
+		name    string
+		command func(cmd *discordgo.ApplicationCommand)
+		want    string
+	}{
+		{"valid", func(cmd *discordgo.ApplicationCommand) {}, ""},
+		{"uppercase name", func(cmd *discordgo.ApplicationCommand) { cmd.Name = "Status" }, "must be lowercase"},
+		{"name with spaces", func(cmd *discordgo.ApplicationCommand) { cmd.Name = "bot status" }, "must be 1-32 letters"},
+		{"empty description", func(cmd *discordgo.ApplicationCommand) { cmd.Description = "" }, "description must be 1-100 characters"},
+		{"long description", func(cmd *discordgo.ApplicationCommand) { cmd.Description = strings.Repeat("a", 101) }, "description must be 1-100 characters"},
+		{"invalid localized name", func(cmd *discordgo.ApplicationCommand) {
+			cmd.NameLocalizations = &map[discordgo.Locale]string{discordgo.German: "Status"}
+		}, "(de): name \"Status\" must be lowercase"},
+		{"duplicate option", func(cmd *discordgo.ApplicationCommand) {
+			cmd.Options = append(cmd.Options, validOption("host", discordgo.ApplicationCommandOptionString))
+		}, "/status host: duplicate option name"},
+		{"required after optional", func(cmd *discordgo.ApplicationCommand) {
+			o := validOption("port", discordgo.ApplicationCommandOptionInteger)
+			o.Required = true
+			cmd.Options = append(cmd.Options, o)
+		}, "required options must come before optional ones"},
+		{"mixed subcommands", func(cmd *discordgo.ApplicationCommand) {
+			cmd.Options = append(cmd.Options, validOption("show", discordgo.ApplicationCommandOptionSubCommand))
+		}, "subcommands can't be mixed with other options"},
+		{"nested subcommand groups", func(cmd *discordgo.ApplicationCommand) {
+			group := validOption("group", discordgo.ApplicationCommandOptionSubCommandGroup)
+			group.Options = []*discordgo.ApplicationCommandOption{validOption("inner", discordgo.ApplicationCommandOptionSubCommandGroup)}
+			cmd.Options = []*discordgo.ApplicationCommandOption{group}
+		}, "subcommand groups can only be nested in commands"},
+		{"choices and autocomplete", func(cmd *discordgo.ApplicationCommand) {
+			cmd.Options[0].Autocomplete = true
+			cmd.Options[0].Choices = []*discordgo.ApplicationCommandOptionChoice{{Name: "a", Value: "a"}}
+		}, "both choices and autocomplete"},
+		{"duplicate choice", func(cmd *discordgo.ApplicationCommand) {
+			cmd.Options[0].Choices = []*discordgo.ApplicationCommandOptionChoice{{Name: "a", Value: "a"}, {Name: "a", Value: "b"}}
+		}, "duplicate choice \"a\""},
+		{"numeric choice for a string", func(cmd *discordgo.ApplicationCommand) {
+			cmd.Options[0].Choices = []*discordgo.ApplicationCommandOptionChoice{{Name: "a", Value: 1}}
+		}, "numeric value for a non-numeric option"},
+		{"min value above max value", func(cmd *discordgo.ApplicationCommand) {
+			o := validOption("port", discordgo.ApplicationCommandOptionInteger)
+			o.MinValue = &two
+			o.MaxValue = 1
+			cmd.Options = append(cmd.Options, o)
+		}, "min value 2 is larger than max value 1"},
+		{"min value above max value of 0", func(cmd *discordgo.ApplicationCommand) {
+			o := validOption("offset", discordgo.ApplicationCommandOptionInteger)
+			o.MinValue = &one
+			setOptionMax(o, 0)
+			cmd.Options = append(cmd.Options, o)
+		}, "min value 1 is larger than max value 0"},
+		{"min value of max value 0", func(cmd *discordgo.ApplicationCommand) {
+			o := validOption("offset", discordgo.ApplicationCommandOptionInteger)
+			o.MinValue = &zero
+			setOptionMax(o, 0)
+			cmd.Options = append(cmd.Options, o)
+		}, ""},
+		{"min value without max value", func(cmd *discordgo.ApplicationCommand) {
+			o := validOption("port", discordgo.ApplicationCommandOptionInteger)
+			o.MinValue = &one
+			cmd.Options = append(cmd.Options, o)
+		}, ""},
+		{"max length of 0", func(cmd *discordgo.ApplicationCommand) { setOptionMax(cmd.Options[0], 0) }, "max length must be 1-6000"},
+		{"max length too long", func(cmd *discordgo.ApplicationCommand) { cmd.Options[0].MaxLength = 6001 }, "max length must be 1-6000"},
+		{"min length above max length", func(cmd *discordgo.ApplicationCommand) {
+			minLength := 10
+			cmd.Options[0].MinLength = &minLength
+			cmd.Options[0].MaxLength = 5
+		}, "min length 10 is larger than max length 5"},
+		{"too many characters", func(cmd *discordgo.ApplicationCommand) {
+			for idx := range 25 {
+				cmd.Options[0].Choices = append(cmd.Options[0].Choices, &discordgo.ApplicationCommandOptionChoice{
+					Name:  strings.Repeat(string(rune('a'+idx)), 100),
+					Value: strings.Repeat("v", 100),
+				})
+			}
+		}, "at most 4000 are allowed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &discordgo.ApplicationCommand{
+				Name:        "status",
+				Description: "Show a server's status",
+				Options:     []*discordgo.ApplicationCommandOption{validOption("host", discordgo.ApplicationCommandOptionString)},
+			}
+			tt.command(cmd)
+
+			errs := ValidateCommand(cmd)
+			if tt.want == "" {
+				if len(errs) > 0 {
+					t.Errorf("ValidateCommand() = %v, want no errors", errs)
+				}
+				return
+			}
+			for _, err := range errs {
+				if strings.Contains(err.Error(), tt.want) {
+					return
+				}
+			}
+			t.Errorf("ValidateCommand() = %v, want an error containing %q", errs, tt.want)
+		})
+	}
+}
+
+func TestValidateCommandsLeavesCommandsUnchanged(t *testing.T) {
+	cmd := &discordgo.ApplicationCommand{Name: "status", Description: "Show a server's status"}
+	b := &Bot{commands: []*discordgo.ApplicationCommand{cmd}}
+	if err := b.ValidateCommands(); err != nil {
+		t.Fatalf("ValidateCommands() error = %v", err)
+	}
+	if cmd.NameLocalizations != nil || cmd.DescriptionLocalizations != nil {
+		t.Error("ValidateCommands() localized the command")
+	}
+}
+
+func TestValidateCommandsComponents(t *testing.T) {
+	b := &Bot{
+		componentHandlers: map[string]InteractionHandler{
+			"help_page":              nil,
+			"help:page":              nil,
+			strings.Repeat("a", 101): nil,
+		},
+		duplicateComponents: []string{"help_page"},
+	}
+	err := b.ValidateCommands()
+	for _, want := range []string{
+		`component "help_page": registered more than once`,
+		`component "help:page": custom ID contains the state separator`,
+		"custom ID longer than 100 characters",
+	} {
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("ValidateCommands() = %v, want an error containing %q", err, want)
+		}
+	}
+}
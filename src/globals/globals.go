@@ -4,6 +4,13 @@ import "os"
 
 //goland:noinspection GoSnakeCaseUsage
 var (
-	NEURALNEXUS_API     = "https://api.neuralnexus.dev/api/v1" // os.Getenv("NEURALNEXUS_API")
+	NEURALNEXUS_API     = envOrDefault("NEURALNEXUS_API", "https://api.neuralnexus.dev/api/v1")
 	NEURALNEXUS_API_KEY = os.Getenv("NEURALNEXUS_API_KEY")
 )
+
+func envOrDefault(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
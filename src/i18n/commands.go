@@ -0,0 +1,47 @@
+package i18n
+
+import (
+	"maps"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// LocalizeCommand fills in the command's name and description localizations from the catalogs.
+// Keys follow the command tree, e.g. "commands.beename.options.upload.options.name.description".
+func LocalizeCommand(cmd *discordgo.ApplicationCommand) {
+	key := "commands." + cmd.Name
+	if cmd.NameLocalizations == nil {
+		cmd.NameLocalizations = &map[discordgo.Locale]string{}
+	}
+	if cmd.DescriptionLocalizations == nil {
+		cmd.DescriptionLocalizations = &map[discordgo.Locale]string{}
+	}
+	maps.Copy(*cmd.NameLocalizations, Localizations(key+".name"))
+	maps.Copy(*cmd.DescriptionLocalizations, Localizations(key+".description"))
+
+	for _, o := range cmd.Options {
+		localizeOption(key, o)
+	}
+}
+
+func localizeOption(parent string, o *discordgo.ApplicationCommandOption) {
+	key := parent + ".options." + o.Name
+	if o.NameLocalizations == nil {
+		o.NameLocalizations = map[discordgo.Locale]string{}
+	}
+	if o.DescriptionLocalizations == nil {
+		o.DescriptionLocalizations = map[discordgo.Locale]string{}
+	}
+	maps.Copy(o.NameLocalizations, Localizations(key+".name"))
+	maps.Copy(o.DescriptionLocalizations, Localizations(key+".description"))
+
+	for _, c := range o.Choices {
+		if c.NameLocalizations == nil {
+			c.NameLocalizations = map[discordgo.Locale]string{}
+		}
+		maps.Copy(c.NameLocalizations, Localizations(key+".choices."+c.Name))
+	}
+	for _, sub := range o.Options {
+		localizeOption(key, sub)
+	}
+}
@@ -0,0 +1,103 @@
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"log"
+	"path"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// DefaultLocale locale used when a message isn't translated into the requested locale
+const DefaultLocale = discordgo.EnglishUS
+
+//go:embed locales/*.json
+var localeFiles embed.FS
+
+// catalogs message catalogs keyed by locale, each mapping a dotted key to its message
+var catalogs = map[discordgo.Locale]map[string]string{}
+
+func init() {
+	entries, err := localeFiles.ReadDir("locales")
+	if err != nil {
+		log.Fatalf("Cannot read message catalogs: %v", err)
+	}
+	for _, entry := range entries {
+		data, err := localeFiles.ReadFile(path.Join("locales", entry.Name()))
+		if err != nil {
+			log.Fatalf("Cannot read message catalog %s: %v", entry.Name(), err)
+		}
+		var raw map[string]any
+		err = json.Unmarshal(data, &raw)
+		if err != nil {
+			log.Fatalf("Invalid message catalog %s: %v", entry.Name(), err)
+		}
+
+		catalog := map[string]string{}
+		flatten("", raw, catalog)
+		catalogs[discordgo.Locale(strings.TrimSuffix(entry.Name(), ".json"))] = catalog
+	}
+}
+
+// flatten turns nested catalog objects into dotted keys
+func flatten(prefix string, raw map[string]any, catalog map[string]string) {
+	for k, v := range raw {
+		if prefix != "" {
+			k = prefix + "." + k
+		}
+		switch v := v.(type) {
+		case string:
+			catalog[k] = v
+		case map[string]any:
+			flatten(k, v, catalog)
+		}
+	}
+}
+
+// baseLocales locale whose catalog stands in for its language, since Discord only has regional locales for some,
+// e.g. es-419 falls back to es-ES
+var baseLocales = map[string]discordgo.Locale{
+	"en": discordgo.EnglishUS,
+	"es": discordgo.SpanishES,
+	"pt": discordgo.PortugueseBR,
+	"sv": discordgo.Swedish,
+	"zh": discordgo.ChineseCN,
+}
+
+// Lookup returns the message for the locale, falling back to the locale's base language and then DefaultLocale
+func Lookup(locale discordgo.Locale, key string) (string, bool) {
+	lang, _, _ := strings.Cut(string(locale), "-")
+	for _, l := range []discordgo.Locale{locale, discordgo.Locale(lang), baseLocales[lang], DefaultLocale} {
+		if msg, ok := catalogs[l][key]; ok {
+			return msg, true
+		}
+	}
+	return "", false
+}
+
+// T returns the formatted message for the locale, or the key itself if no catalog has it
+func T(locale discordgo.Locale, key string, args ...any) string {
+	msg, ok := Lookup(locale, key)
+	if !ok {
+		log.Printf("Missing message %q", key)
+		return key
+	}
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
+
+// Localizations returns the translations of key for every locale other than DefaultLocale
+func Localizations(key string) map[discordgo.Locale]string {
+	localizations := map[discordgo.Locale]string{}
+	for locale, catalog := range catalogs {
+		if msg, ok := catalog[key]; ok && locale != DefaultLocale {
+			localizations[locale] = msg
+		}
+	}
+	return localizations
+}
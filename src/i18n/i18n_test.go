@@ -0,0 +1,40 @@
+package i18n
+
+import (
+	"regexp"
+	"slices"
+	"sort"
+	"testing"
+)
+
+// verbPattern matches the formatting verbs of a message
+var verbPattern = regexp.MustCompile(`%(\[\d+\])?[-+# 0]*\d*(\.\d+)?[a-zA-Z%]`)
+
+// verbs returns the sorted formatting verbs of msg, so a translation can reorder them
+func verbs(msg string) []string {
+	found := verbPattern.FindAllString(msg, -1)
+	sort.Strings(found)
+	return found
+}
+
+func TestCatalogsComplete(t *testing.T) {
+	base := catalogs[DefaultLocale]
+	if len(base) == 0 {
+		t.Fatalf("no messages for %s", DefaultLocale)
+	}
+	for locale, catalog := range catalogs {
+		if locale == DefaultLocale {
+			continue
+		}
+		for key, msg := range base {
+			translated, ok := catalog[key]
+			if !ok {
+				t.Errorf("%s: missing %q", locale, key)
+				continue
+			}
+			if !slices.Equal(verbs(msg), verbs(translated)) {
+				t.Errorf("%s: %q has verbs %v, want %v", locale, key, verbs(translated), verbs(msg))
+			}
+		}
+	}
+}
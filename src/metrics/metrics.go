@@ -0,0 +1,129 @@
+package metrics
+
+import (
+	"expvar"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Counter monotonically increasing counter, published through expvar
+type Counter struct {
+	v atomic.Uint64
+}
+
+// NewCounter returns a counter published as name
+func NewCounter(name string) *Counter {
+	c := &Counter{}
+	expvar.Publish(name, expvar.Func(func() any { return c.Value() }))
+	return c
+}
+
+// Inc increments the counter
+func (c *Counter) Inc() {
+	c.v.Add(1)
+}
+
+// Add adds n to the counter
+func (c *Counter) Add(n uint64) {
+	c.v.Add(n)
+}
+
+// Value returns the counter's value
+func (c *Counter) Value() uint64 {
+	return c.v.Load()
+}
+
+// Timer tracks the duration of repeated operations
+type Timer struct {
+	mu    sync.Mutex
+	count uint64
+	total time.Duration
+	last  time.Duration
+}
+
+// TimerStats snapshot of a timer
+type TimerStats struct {
+	Count   uint64        `json:"count"`
+	Average time.Duration `json:"average"`
+	Last    time.Duration `json:"last"`
+}
+
+// NewTimer returns a timer published as name
+func NewTimer(name string) *Timer {
+	t := &Timer{}
+	expvar.Publish(name, expvar.Func(func() any { return t.Stats() }))
+	return t
+}
+
+// Observe records the duration of one operation
+func (t *Timer) Observe(d time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.count++
+	t.total += d
+	t.last = d
+}
+
+// Since records the time elapsed since start
+func (t *Timer) Since(start time.Time) {
+	t.Observe(time.Since(start))
+}
+
+// Stats returns a snapshot of the timer
+func (t *Timer) Stats() TimerStats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	stats := TimerStats{Count: t.count, Last: t.last}
+	if t.count > 0 {
+		stats.Average = t.total / time.Duration(t.count)
+	}
+	return stats
+}
+
+// CacheStats cache hit and miss counters
+type CacheStats struct {
+	Name   string
+	Hits   *Counter
+	Misses *Counter
+}
+
+var (
+	cachesMu sync.Mutex
+	caches   = map[string]*CacheStats{}
+)
+
+// NewCacheStats returns the hit and miss counters for the named cache, published as "cache.<name>.hits" and "cache.<name>.misses"
+func NewCacheStats(name string) *CacheStats {
+	cachesMu.Lock()
+	defer cachesMu.Unlock()
+	stats := &CacheStats{
+		Name:   name,
+		Hits:   NewCounter("cache." + name + ".hits"),
+		Misses: NewCounter("cache." + name + ".misses"),
+	}
+	caches[name] = stats
+	return stats
+}
+
+// HitRate returns the fraction of lookups that were hits, or 0 if there were none
+func (c *CacheStats) HitRate() float64 {
+	hits, misses := c.Hits.Value(), c.Misses.Value()
+	if hits+misses == 0 {
+		return 0
+	}
+	return float64(hits) / float64(hits+misses)
+}
+
+// Caches returns the registered cache stats, sorted by name
+func Caches() []*CacheStats {
+	cachesMu.Lock()
+	defer cachesMu.Unlock()
+	var stats []*CacheStats
+	for _, c := range caches {
+		stats = append(stats, c)
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Name < stats[j].Name })
+	return stats
+}
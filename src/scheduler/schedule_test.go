@@ -0,0 +1,92 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCronParse(t *testing.T) {
+	tests := []struct {
+		expr    string
+		wantErr bool
+	}{
+		{expr: "* * * * *"},
+		{expr: "*/5 * * * *"},
+		{expr: "0 0 1 1 *"},
+		{expr: "0,30 9-17 * * 1-5"},
+		{expr: "10-50/20 * * * *"},
+		{expr: "5/15 * * * *"},
+		{expr: "0 0 * * 7"},
+		{expr: "@daily"},
+		{expr: "  @hourly  "},
+		{expr: "", wantErr: true},
+		{expr: "* * * *", wantErr: true},
+		{expr: "* * * * * *", wantErr: true},
+		{expr: "60 * * * *", wantErr: true},
+		{expr: "* 24 * * *", wantErr: true},
+		{expr: "* * 0 * *", wantErr: true},
+		{expr: "* * 32 * *", wantErr: true},
+		{expr: "* * * 13 *", wantErr: true},
+		{expr: "* * * * 8", wantErr: true},
+		{expr: "*/0 * * * *", wantErr: true},
+		{expr: "*/x * * * *", wantErr: true},
+		{expr: "5-1 * * * *", wantErr: true},
+		{expr: "a * * * *", wantErr: true},
+		{expr: "@never", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			_, err := Cron(tt.expr)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Cron(%q) error = %v, want error %v", tt.expr, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCronNext(t *testing.T) {
+	// Monday
+	base := time.Date(2024, 1, 15, 10, 7, 30, 0, time.UTC)
+	tests := []struct {
+		name string
+		expr string
+		from time.Time
+		want time.Time
+	}{
+		{"every minute", "* * * * *", base, time.Date(2024, 1, 15, 10, 8, 0, 0, time.UTC)},
+		{"strictly after", "* * * * *", time.Date(2024, 1, 15, 10, 8, 0, 0, time.UTC), time.Date(2024, 1, 15, 10, 9, 0, 0, time.UTC)},
+		{"step", "*/5 * * * *", base, time.Date(2024, 1, 15, 10, 10, 0, 0, time.UTC)},
+		{"hourly", "@hourly", base, time.Date(2024, 1, 15, 11, 0, 0, 0, time.UTC)},
+		{"daily", "@daily", base, time.Date(2024, 1, 16, 0, 0, 0, 0, time.UTC)},
+		{"weekly on sunday", "@weekly", base, time.Date(2024, 1, 21, 0, 0, 0, 0, time.UTC)},
+		{"sunday as 7", "0 0 * * 7", base, time.Date(2024, 1, 21, 0, 0, 0, 0, time.UTC)},
+		{"month rollover", "0 0 1 * *", base, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"year rollover", "@yearly", base, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"weekdays", "30 9 * * 1-5", time.Date(2024, 1, 19, 10, 0, 0, 0, time.UTC), time.Date(2024, 1, 22, 9, 30, 0, 0, time.UTC)},
+		{"day of month or week", "0 0 1 * 3", base, time.Date(2024, 1, 17, 0, 0, 0, 0, time.UTC)},
+		{"leap day", "0 0 29 2 *", base, time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"skips short months", "0 0 31 * *", time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 5, 31, 0, 0, 0, 0, time.UTC)},
+		{"impossible date", "0 0 30 2 *", base, time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := MustCron(tt.expr).Next(tt.from)
+			if !got.Equal(tt.want) {
+				t.Errorf("Next(%v) = %v, want %v", tt.from, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCronNextIn(t *testing.T) {
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	from := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	got := MustCron("0 0 * * *").In(loc).Next(from)
+	want := time.Date(2024, 1, 15, 22, 0, 0, 0, time.UTC)
+	if !got.Equal(want) {
+		t.Errorf("Next(%v) = %v, want %v", from, got, want)
+	}
+	if got.Location() != time.UTC {
+		t.Errorf("Next returned location %v, want the argument's", got.Location())
+	}
+}
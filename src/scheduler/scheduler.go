@@ -0,0 +1,186 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// Job periodic job
+type Job struct {
+	// Name unique job name, used to persist the job's last run time
+	Name string
+	// Schedule decides when the job runs
+	Schedule Schedule
+	// Jitter upper bound of a random delay added to every run, spreading load across instances
+	Jitter time.Duration
+	// Run runs the job, ctx is cancelled when the scheduler stops
+	Run func(ctx context.Context) error
+}
+
+// LastRunStore persists when each job last ran, so schedules survive restarts
+type LastRunStore interface {
+	LastRun(name string) (time.Time, error)
+	SetLastRun(name string, t time.Time) error
+}
+
+// Scheduler runs registered jobs on their schedules until stopped.
+// Each job runs in its own goroutine and the next run is only scheduled once the current one has finished,
+// so a job never overlaps with itself; runs missed while a job was busy are skipped.
+type Scheduler struct {
+	// OnError called when a job fails or panics, in addition to the failure being logged
+	OnError func(job string, err error)
+
+	mu      sync.Mutex
+	store   LastRunStore
+	jobs    map[string]*Job
+	running bool
+	ctx     context.Context
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+}
+
+// New returns a scheduler persisting last run times to store, which may be nil
+func New(store LastRunStore) *Scheduler {
+	return &Scheduler{
+		store: store,
+		jobs:  map[string]*Job{},
+	}
+}
+
+// Add registers a job, jobs added after Start begin running immediately.
+// Schedules that never run, like a non-positive interval or an impossible cron date, are rejected.
+func (s *Scheduler) Add(job Job) error {
+	if job.Name == "" || job.Schedule == nil || job.Run == nil {
+		return errors.New("scheduler: job needs a name, schedule and run function")
+	}
+	if now := time.Now(); !job.Schedule.Next(now).After(now) {
+		return fmt.Errorf("scheduler: job %q has a schedule that never runs", job.Name)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.jobs[job.Name]; ok {
+		return fmt.Errorf("scheduler: job %q already registered", job.Name)
+	}
+	s.jobs[job.Name] = &job
+	if s.running {
+		s.wg.Add(1)
+		go s.loop(s.ctx, &job)
+	}
+	return nil
+}
+
+// Start starts running the registered jobs until ctx is cancelled or Stop is called
+func (s *Scheduler) Start(ctx context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.running {
+		return
+	}
+	s.ctx, s.cancel = context.WithCancel(ctx)
+	s.running = true
+	for _, job := range s.jobs {
+		s.wg.Add(1)
+		go s.loop(s.ctx, job)
+	}
+}
+
+// Stop cancels running jobs and waits for them to return
+func (s *Scheduler) Stop() {
+	s.mu.Lock()
+	if !s.running {
+		s.mu.Unlock()
+		return
+	}
+	s.running = false
+	s.cancel()
+	s.mu.Unlock()
+
+	s.wg.Wait()
+}
+
+func (s *Scheduler) loop(ctx context.Context, job *Job) {
+	defer s.wg.Done()
+
+	next := s.firstRun(job)
+	for {
+		if next.IsZero() {
+			log.Printf("Job %q has no further runs scheduled", job.Name)
+			return
+		}
+		if job.Jitter > 0 {
+			next = next.Add(rand.N(job.Jitter))
+		}
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		started := time.Now()
+		s.run(ctx, job)
+		if ctx.Err() != nil {
+			return
+		}
+		if s.store != nil {
+			err := s.store.SetLastRun(job.Name, started)
+			if err != nil {
+				log.Printf("Cannot persist last run of job %q: %v", job.Name, err)
+			}
+		}
+
+		next = job.Schedule.Next(started)
+		if now := time.Now(); !next.IsZero() && next.Before(now) {
+			log.Printf("Job %q overran its schedule, skipping missed runs", job.Name)
+			next = job.Schedule.Next(now)
+		}
+	}
+}
+
+// firstRun returns when the job should first run, catching up at once if a run was missed while the bot was down
+func (s *Scheduler) firstRun(job *Job) time.Time {
+	now := time.Now()
+	if s.store == nil {
+		return job.Schedule.Next(now)
+	}
+	last, err := s.store.LastRun(job.Name)
+	if err != nil || last.IsZero() {
+		return job.Schedule.Next(now)
+	}
+	next := job.Schedule.Next(last)
+	if next.IsZero() {
+		return job.Schedule.Next(now)
+	}
+	if next.Before(now) {
+		return now
+	}
+	return next
+}
+
+// run runs the job, recovering from panics so one bad job can't take down the bot
+func (s *Scheduler) run(ctx context.Context, job *Job) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("Job %q panicked: %v", job.Name, r)
+			s.failed(job, fmt.Errorf("panic: %v", r))
+		}
+	}()
+	err := job.Run(ctx)
+	if err != nil && ctx.Err() == nil {
+		log.Printf("Job %q failed: %v", job.Name, err)
+		s.failed(job, err)
+	}
+}
+
+func (s *Scheduler) failed(job *Job, err error) {
+	if s.OnError != nil {
+		s.OnError(job.Name, err)
+	}
+}
@@ -0,0 +1,30 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestAddRejectsSchedulesThatNeverRun(t *testing.T) {
+	run := func(context.Context) error { return nil }
+	tests := []struct {
+		name     string
+		schedule Schedule
+		wantErr  bool
+	}{
+		{"interval", Every(time.Minute), false},
+		{"cron", MustCron("@daily"), false},
+		{"zero interval", Every(0), true},
+		{"negative interval", Every(-time.Minute), true},
+		{"impossible cron date", MustCron("0 0 30 2 *"), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := New(nil).Add(Job{Name: "job", Schedule: tt.schedule, Run: run})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Add() error = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
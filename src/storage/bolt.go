@@ -0,0 +1,124 @@
+package storage
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// BoltStore Store backed by a bbolt database file
+type BoltStore struct {
+	db *bolt.DB
+}
+
+// OpenBolt opens the bbolt database at path and runs any pending migrations
+func OpenBolt(path string, migrations []Migration) (*BoltStore, error) {
+	err := os.MkdirAll(filepath.Dir(path), 0o755)
+	if err != nil {
+		return nil, err
+	}
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, err
+	}
+	store := &BoltStore{db: db}
+
+	err = Migrate(store, migrations)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return store, nil
+}
+
+// View runs fn in a read-only transaction
+func (s *BoltStore) View(fn func(tx Tx) error) error {
+	return s.db.View(func(tx *bolt.Tx) error {
+		return fn(&boltTx{tx: tx})
+	})
+}
+
+// Update runs fn in a read-write transaction
+func (s *BoltStore) Update(fn func(tx Tx) error) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return fn(&boltTx{tx: tx})
+	})
+}
+
+// Close closes the database
+func (s *BoltStore) Close() error {
+	return s.db.Close()
+}
+
+type boltTx struct {
+	tx *bolt.Tx
+}
+
+func (t *boltTx) CreateBucket(bucket string) error {
+	_, err := t.tx.CreateBucketIfNotExists([]byte(bucket))
+	return err
+}
+
+func (t *boltTx) DeleteBucket(bucket string) error {
+	err := t.tx.DeleteBucket([]byte(bucket))
+	if err == bolt.ErrBucketNotFound {
+		return nil
+	}
+	return err
+}
+
+func (t *boltTx) Get(bucket, key string) ([]byte, error) {
+	b := t.tx.Bucket([]byte(bucket))
+	if b == nil {
+		return nil, ErrNotFound
+	}
+	v := b.Get([]byte(key))
+	if v == nil {
+		return nil, ErrNotFound
+	}
+	// bbolt values are only valid for the life of the transaction
+	return bytes.Clone(v), nil
+}
+
+func (t *boltTx) Put(bucket, key string, value []byte) error {
+	b := t.tx.Bucket([]byte(bucket))
+	if b == nil {
+		return ErrBucketNotFound
+	}
+	return b.Put([]byte(key), value)
+}
+
+func (t *boltTx) Delete(bucket, key string) error {
+	b := t.tx.Bucket([]byte(bucket))
+	if b == nil {
+		return nil
+	}
+	return b.Delete([]byte(key))
+}
+
+func (t *boltTx) NextSequence(bucket string) (uint64, error) {
+	b := t.tx.Bucket([]byte(bucket))
+	if b == nil {
+		return 0, ErrBucketNotFound
+	}
+	return b.NextSequence()
+}
+
+func (t *boltTx) ForEach(bucket, prefix string, fn func(key string, value []byte) error) error {
+	b := t.tx.Bucket([]byte(bucket))
+	if b == nil {
+		return nil
+	}
+	p := []byte(prefix)
+	c := b.Cursor()
+	for k, v := c.Seek(p); k != nil && bytes.HasPrefix(k, p); k, v = c.Next() {
+		err := fn(string(k), bytes.Clone(v))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package storage
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+)
+
+const (
+	metaBucket       = "_meta"
+	schemaVersionKey = "schema_version"
+)
+
+// Migration schema migration, applied once in order of Version
+type Migration struct {
+	Version int
+	Name    string
+	Up      func(tx Tx) error
+}
+
+// Migrate applies the migrations newer than the store's schema version, each in its own transaction
+func Migrate(store Store, migrations []Migration) error {
+	version, err := SchemaVersion(store)
+	if err != nil {
+		return err
+	}
+
+	last := 0
+	for _, m := range migrations {
+		if m.Version <= last {
+			return fmt.Errorf("storage: migration %d (%s) is out of order", m.Version, m.Name)
+		}
+		last = m.Version
+		if m.Version <= version {
+			continue
+		}
+
+		log.Printf("Applying storage migration %d: %s", m.Version, m.Name)
+		err := store.Update(func(tx Tx) error {
+			err := tx.CreateBucket(metaBucket)
+			if err != nil {
+				return err
+			}
+			err = m.Up(tx)
+			if err != nil {
+				return err
+			}
+			return tx.Put(metaBucket, schemaVersionKey, []byte(strconv.Itoa(m.Version)))
+		})
+		if err != nil {
+			return fmt.Errorf("storage: migration %d (%s) failed: %w", m.Version, m.Name, err)
+		}
+	}
+	return nil
+}
+
+// SchemaVersion returns the version of the last migration applied to the store
+func SchemaVersion(store Store) (int, error) {
+	version := 0
+	err := store.View(func(tx Tx) error {
+		v, err := tx.Get(metaBucket, schemaVersionKey)
+		if err == ErrNotFound {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		version, err = strconv.Atoi(string(v))
+		return err
+	})
+	return version, err
+}
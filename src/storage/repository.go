@@ -0,0 +1,149 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// Repository typed, JSON encoded view of a bucket
+type Repository[T any] struct {
+	store  Store
+	bucket string
+}
+
+// NewRepository returns a repository for the bucket
+func NewRepository[T any](store Store, bucket string) *Repository[T] {
+	return &Repository[T]{
+		store:  store,
+		bucket: bucket,
+	}
+}
+
+// Get returns the value stored at key, or ErrNotFound
+func (r *Repository[T]) Get(key string) (*T, error) {
+	var value *T
+	err := r.store.View(func(tx Tx) error {
+		var err error
+		value, err = r.get(tx, key)
+		return err
+	})
+	return value, err
+}
+
+// Put stores the value at key
+func (r *Repository[T]) Put(key string, value *T) error {
+	return r.store.Update(func(tx Tx) error {
+		return r.put(tx, key, value)
+	})
+}
+
+// Insert stores the value at the key built from the bucket's next sequence number, so keys are unique even when
+// they're otherwise the same
+func (r *Repository[T]) Insert(key func(seq uint64) string, value *T) error {
+	return r.store.Update(func(tx Tx) error {
+		seq, err := tx.NextSequence(r.bucket)
+		if err != nil {
+			return err
+		}
+		return r.put(tx, key(seq), value)
+	})
+}
+
+// Update atomically applies fn to the value at key, starting from the zero value if it doesn't exist
+func (r *Repository[T]) Update(key string, fn func(value *T) error) (*T, error) {
+	var value *T
+	err := r.store.Update(func(tx Tx) error {
+		var err error
+		value, err = r.get(tx, key)
+		if errors.Is(err, ErrNotFound) {
+			value = new(T)
+		} else if err != nil {
+			return err
+		}
+		err = fn(value)
+		if err != nil {
+			return err
+		}
+		return r.put(tx, key, value)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// Delete removes the value at key
+func (r *Repository[T]) Delete(key string) error {
+	return r.store.Update(func(tx Tx) error {
+		return tx.Delete(r.bucket, key)
+	})
+}
+
+// List returns the values whose keys have the given prefix, in key order
+func (r *Repository[T]) List(prefix string) ([]*T, error) {
+	var values []*T
+	err := r.store.View(func(tx Tx) error {
+		return tx.ForEach(r.bucket, prefix, func(_ string, data []byte) error {
+			value := new(T)
+			err := json.Unmarshal(data, value)
+			if err != nil {
+				return err
+			}
+			values = append(values, value)
+			return nil
+		})
+	})
+	return values, err
+}
+
+// DeleteFunc deletes the values whose keys have the given prefix and for which fn returns true, returning how many were deleted
+func (r *Repository[T]) DeleteFunc(prefix string, fn func(value *T) bool) (int, error) {
+	var deleted int
+	err := r.store.Update(func(tx Tx) error {
+		var keys []string
+		err := tx.ForEach(r.bucket, prefix, func(key string, data []byte) error {
+			value := new(T)
+			err := json.Unmarshal(data, value)
+			if err != nil {
+				return err
+			}
+			if fn(value) {
+				keys = append(keys, key)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, key := range keys {
+			err = tx.Delete(r.bucket, key)
+			if err != nil {
+				return err
+			}
+		}
+		deleted = len(keys)
+		return nil
+	})
+	return deleted, err
+}
+
+func (r *Repository[T]) get(tx Tx, key string) (*T, error) {
+	data, err := tx.Get(r.bucket, key)
+	if err != nil {
+		return nil, err
+	}
+	value := new(T)
+	err = json.Unmarshal(data, value)
+	if err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+func (r *Repository[T]) put(tx Tx, key string, value *T) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return tx.Put(r.bucket, key, data)
+}
@@ -0,0 +1,38 @@
+package storage
+
+import "errors"
+
+var (
+	// ErrNotFound returned when a key doesn't exist
+	ErrNotFound = errors.New("storage: key not found")
+	// ErrBucketNotFound returned when writing to a bucket no migration has created
+	ErrBucketNotFound = errors.New("storage: bucket not found")
+)
+
+// Store persistent key/value storage, with keys grouped into buckets
+type Store interface {
+	// View runs fn in a read-only transaction
+	View(fn func(tx Tx) error) error
+	// Update runs fn in a read-write transaction, rolling back if fn returns an error
+	Update(fn func(tx Tx) error) error
+	// Close closes the store
+	Close() error
+}
+
+// Tx storage transaction
+type Tx interface {
+	// CreateBucket creates the bucket if it doesn't exist
+	CreateBucket(bucket string) error
+	// DeleteBucket deletes the bucket and all of its keys
+	DeleteBucket(bucket string) error
+	// Get returns the value stored at key, or ErrNotFound
+	Get(bucket, key string) ([]byte, error)
+	// Put stores the value at key
+	Put(bucket, key string, value []byte) error
+	// Delete removes the key, deleting a missing key is not an error
+	Delete(bucket, key string) error
+	// NextSequence returns the bucket's next sequence number, unique within the bucket
+	NextSequence(bucket string) (uint64, error)
+	// ForEach calls fn for every key with the given prefix, in key order
+	ForEach(bucket, prefix string, fn func(key string, value []byte) error) error
+}
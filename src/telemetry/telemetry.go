@@ -0,0 +1,79 @@
+// Package telemetry OpenTelemetry tracing setup.
+//
+// Traces are exported as OTEL_TRACES_EXPORTER says: "otlp" sends them over OTLP/HTTP, configured with the standard
+// OTEL_EXPORTER_OTLP_* variables, "console" or "stdout" prints them, and "none" or unset disables tracing.
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName name of the bot's tracer
+const instrumentationName = "github.com/NeuralNexusDev/neuralnexus-discord-bot"
+
+//goland:noinspection GoSnakeCaseUsage
+var (
+	OTEL_TRACES_EXPORTER = os.Getenv("OTEL_TRACES_EXPORTER")
+	OTEL_SERVICE_NAME    = envOrDefault("OTEL_SERVICE_NAME", "neuralnexus-discord-bot")
+)
+
+// Tracer returns the bot's tracer, a no-op one until Setup enables tracing
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// Setup installs the tracer provider and trace context propagation for the configured exporter.
+// The returned function flushes and stops the exporter.
+func Setup(ctx context.Context, version string) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch OTEL_TRACES_EXPORTER {
+	case "", "none":
+		return func(context.Context) error { return nil }, nil
+	case "otlp":
+		exporter, err = otlptracehttp.New(ctx)
+	case "console", "stdout":
+		exporter, err = stdouttrace.New(stdouttrace.WithPrettyPrint())
+	default:
+		return nil, fmt.Errorf("unknown OTEL_TRACES_EXPORTER %q, expected otlp, console or none", OTEL_TRACES_EXPORTER)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("creating %s trace exporter: %w", OTEL_TRACES_EXPORTER, err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(
+		semconv.ServiceName(OTEL_SERVICE_NAME),
+		semconv.ServiceVersion(version),
+	))
+	if err != nil && !errors.Is(err, resource.ErrSchemaURLConflict) {
+		return nil, fmt.Errorf("creating trace resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+func envOrDefault(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}